@@ -0,0 +1,111 @@
+// Package client fetches events from the GitHub Events API, optionally
+// recording or replaying the HTTP exchanges as fixtures.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+// Client talks to the GitHub API at BaseURL through HTTPClient, retrying
+// transient failures according to Retry. Requests are authenticated with
+// Token when it is set.
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+	Token      string
+	Retry      RetryPolicy
+}
+
+// New returns a Client for api.github.com that sends its requests through
+// httpClient with the default retry policy.
+func New(httpClient *http.Client) Client {
+	return Client{HTTPClient: httpClient, BaseURL: DEFAULT_API_URL, Retry: DefaultRetryPolicy()}
+}
+
+// FetchUserEvents fetches one page of a user's public events. The request is
+// abandoned when ctx is done, with the cause of ctx as the error, and retried
+// while it fails for a transient reason.
+func (client Client) FetchUserEvents(ctx context.Context, username, page, perPage string) ([]events.Event, error) {
+	return client.fetchEvents(ctx, "users/"+username, page, perPage)
+}
+
+// FetchOrgEvents fetches one page of an organization's public events, like
+// FetchUserEvents does for a user.
+func (client Client) FetchOrgEvents(ctx context.Context, org, page, perPage string) ([]events.Event, error) {
+	return client.fetchEvents(ctx, "orgs/"+org, page, perPage)
+}
+
+// FetchAllUserEvents fetches every event the API exposes for a user, up to
+// MAX_EVENTS.
+func (client Client) FetchAllUserEvents(ctx context.Context, username string) ([]events.Event, error) {
+	return client.fetchAllEvents(ctx, "users/"+username)
+}
+
+// FetchAllOrgEvents fetches every event the API exposes for an organization,
+// up to MAX_EVENTS.
+func (client Client) FetchAllOrgEvents(ctx context.Context, org string) ([]events.Event, error) {
+	return client.fetchAllEvents(ctx, "orgs/"+org)
+}
+
+// fetchEvents fetches one page of the event feed below owner, such as
+// users/octocat.
+func (client Client) fetchEvents(ctx context.Context, owner, page, perPage string) ([]events.Event, error) {
+	url := fmt.Sprintf(
+		"%s/%s/events?page=%s&per_page=%s",
+		strings.TrimRight(client.BaseURL, "/"),
+		owner,
+		page,
+		perPage,
+	)
+
+	res, err := client.get(ctx, url)
+	if err != nil {
+		return []events.Event{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode > 399 {
+		return []events.Event{}, newStatusError(res)
+	}
+
+	var dat []events.Event
+	if err := json.NewDecoder(res.Body).Decode(&dat); err != nil {
+		if ctx.Err() != nil {
+			return []events.Event{}, context.Cause(ctx)
+		}
+		return []events.Event{}, err
+	}
+
+	return dat, nil
+}
+
+func (client Client) fetchAllEvents(ctx context.Context, owner string) ([]events.Event, error) {
+	var activities []events.Event
+
+	for page := 1; page*MAX_PER_PAGE_EVENTS <= MAX_EVENTS; page++ {
+		dat, err := client.fetchEvents(
+			ctx,
+			owner,
+			strconv.Itoa(page),
+			strconv.Itoa(MAX_PER_PAGE_EVENTS),
+		)
+		if err != nil {
+			return []events.Event{}, err
+		}
+
+		activities = append(activities, dat...)
+
+		if len(dat) < MAX_PER_PAGE_EVENTS {
+			break
+		}
+	}
+
+	return activities, nil
+}
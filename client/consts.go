@@ -0,0 +1,28 @@
+package client
+
+import "time"
+
+const (
+	DEFAULT_API_URL     = "https://api.github.com"
+	DEFAULT_HOST        = "github.com"
+	API_HOST            = "api.github.com"
+	ENTERPRISE_API_PATH = "/api/v3"
+	GHE_CLOUD_DOMAIN    = "ghe.com"
+	FIXTURE_EXT         = ".json"
+	REDACTED            = "REDACTED"
+)
+
+const (
+	MAX_PER_PAGE_EVENTS = 100
+	MAX_EVENTS          = 300
+)
+
+const (
+	DEFAULT_MAX_ATTEMPTS         = 3
+	DEFAULT_RETRY_BASE_DELAY     = 500 * time.Millisecond
+	DEFAULT_RETRY_MAX_DELAY      = 30 * time.Second
+	MAX_RETRY_DELAY              = time.Hour
+	SECONDARY_RATE_LIMIT_MESSAGE = "secondary rate limit"
+	RATE_LIMIT_MESSAGE           = "rate limit"
+	MAX_ERROR_BODY_SIZE          = 64 * 1024
+)
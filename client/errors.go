@@ -0,0 +1,46 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// StatusError is returned for an API response with an error status.
+type StatusError struct {
+	StatusCode int
+	// Message is GitHub's explanation from the response body, if any
+	Message string
+	// RateLimited is set when the request was refused for exceeding the
+	// primary or a secondary rate limit
+	RateLimited bool
+}
+
+func (err StatusError) Error() string {
+	text := fmt.Sprintf("couldn't get response from github: %d %s", err.StatusCode, http.StatusText(err.StatusCode))
+	if err.Message != "" {
+		text += ": " + err.Message
+	}
+	return text
+}
+
+func newStatusError(res *http.Response) StatusError {
+	var body struct {
+		Message string `json:"message"`
+	}
+	json.NewDecoder(io.LimitReader(res.Body, MAX_ERROR_BODY_SIZE)).Decode(&body)
+
+	rateLimited := res.StatusCode == http.StatusTooManyRequests
+	if res.StatusCode == http.StatusForbidden {
+		rateLimited = res.Header.Get("X-RateLimit-Remaining") == "0" ||
+			strings.Contains(strings.ToLower(body.Message), RATE_LIMIT_MESSAGE)
+	}
+
+	return StatusError{
+		StatusCode:  res.StatusCode,
+		Message:     body.Message,
+		RateLimited: rateLimited,
+	}
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchUserEventsStatusError(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		header      map[string]string
+		body        string
+		rateLimited bool
+		message     string
+	}{
+		{name: "Not found", status: http.StatusNotFound, body: `{"message":"Not Found"}`, message: "Not Found"},
+		{name: "Bad credentials", status: http.StatusUnauthorized, body: `{"message":"Bad credentials"}`, message: "Bad credentials"},
+		{name: "Forbidden", status: http.StatusForbidden, body: `{"message":"Resource not accessible"}`, message: "Resource not accessible"},
+		{
+			name:        "Primary rate limit",
+			status:      http.StatusForbidden,
+			header:      map[string]string{"X-RateLimit-Remaining": "0"},
+			body:        `{"message":"API rate limit exceeded for 1.2.3.4."}`,
+			rateLimited: true,
+			message:     "API rate limit exceeded for 1.2.3.4.",
+		},
+		{name: "Too many requests", status: http.StatusTooManyRequests, rateLimited: true},
+		{name: "Body that isn't JSON", status: http.StatusBadGateway, body: "<html>Bad gateway</html>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for name, value := range tt.header {
+					w.Header().Set(name, value)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := Client{HTTPClient: server.Client(), BaseURL: server.URL}
+			_, err := client.FetchUserEvents(context.Background(), "octocat", "1", "30")
+
+			var status StatusError
+			if !errors.As(err, &status) {
+				t.Fatalf("Expected a StatusError, got %v", err)
+			}
+			if status.StatusCode != tt.status || status.RateLimited != tt.rateLimited || status.Message != tt.message {
+				t.Errorf("Unexpected status error: %+v", status)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Fixture is a recorded request/response pair as stored on disk by a
+// RecordingTransport and served back by a ReplayTransport.
+type Fixture struct {
+	Request struct {
+		Method string      `json:"method"`
+		URL    string      `json:"url"`
+		Header http.Header `json:"header"`
+	} `json:"request"`
+	Response struct {
+		StatusCode int         `json:"status_code"`
+		Header     http.Header `json:"header"`
+		Body       string      `json:"body"`
+	} `json:"response"`
+}
+
+var fixtureNameReplacer = regexp.MustCompile(`[^A-Za-z0-9._=-]+`)
+
+// RecordingTransport passes requests on to Next and saves every exchange to
+// a fixture file in Dir.
+type RecordingTransport struct {
+	Dir  string
+	Next http.RoundTripper
+}
+
+// ReplayTransport answers requests from fixture files in Dir without
+// touching the network.
+type ReplayTransport struct {
+	Dir string
+}
+
+// NewFixtureClient returns an HTTP client that records its exchanges to
+// recordDir or replays them from replayDir. With neither set it is a plain
+// client. Requests that do reach the network go through transport, or
+// http.DefaultTransport when it is nil.
+func NewFixtureClient(recordDir, replayDir string, transport http.RoundTripper) (*http.Client, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	switch {
+	case recordDir != "" && replayDir != "":
+		return nil, errors.New("--record and --replay are mutually exclusive")
+	case recordDir != "":
+		if err := os.MkdirAll(recordDir, 0o700); err != nil {
+			return nil, err
+		}
+		return &http.Client{Transport: RecordingTransport{Dir: recordDir, Next: transport}}, nil
+	case replayDir != "":
+		return &http.Client{Transport: ReplayTransport{Dir: replayDir}}, nil
+	default:
+		return &http.Client{Transport: transport}, nil
+	}
+}
+
+func fixturePath(dir string, req *http.Request) string {
+	name := req.Method + "_" + req.URL.Host + req.URL.Path
+	if req.URL.RawQuery != "" {
+		name += "_" + req.URL.RawQuery
+	}
+
+	return filepath.Join(dir, strings.Trim(fixtureNameReplacer.ReplaceAllString(name, "_"), "_")+FIXTURE_EXT)
+}
+
+func (t RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.Next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	var fixture Fixture
+	fixture.Request.Method = req.Method
+	fixture.Request.URL = req.URL.String()
+	fixture.Request.Header = req.Header.Clone()
+	if fixture.Request.Header.Get("Authorization") != "" {
+		fixture.Request.Header.Set("Authorization", REDACTED)
+	}
+	fixture.Response.StatusCode = res.StatusCode
+	fixture.Response.Header = res.Header.Clone()
+	fixture.Response.Body = string(body)
+
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(fixturePath(t.Dir, req), data, 0o600); err != nil {
+		return nil, fmt.Errorf("couldn't record response: %v", err)
+	}
+
+	return res, nil
+}
+
+func (t ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	data, err := os.ReadFile(fixturePath(t.Dir, req))
+	if err != nil {
+		return nil, fmt.Errorf("no recorded response for %s %s", req.Method, req.URL)
+	}
+
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("couldn't decode recorded response for %s %s: %v", req.Method, req.URL, err)
+	}
+
+	if fixture.Response.Header == nil {
+		fixture.Response.Header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fixture.Response.StatusCode, http.StatusText(fixture.Response.StatusCode)),
+		StatusCode:    fixture.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        fixture.Response.Header,
+		Body:          io.NopCloser(strings.NewReader(fixture.Response.Body)),
+		ContentLength: int64(len(fixture.Response.Body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "59")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `[{"type":"PushEvent"}]`)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recordClient, err := NewFixtureClient(dir, "", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	req, _ := http.NewRequest("GET", server.URL+"/users/octocat/events?page=1", nil)
+	req.Header.Set("Authorization", "Bearer secret-token")
+
+	res, err := recordClient.Do(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	if string(body) != `[{"type":"PushEvent"}]` {
+		t.Errorf("Expected recording to pass the body through, got %q", body)
+	}
+
+	fixtures, _ := filepath.Glob(filepath.Join(dir, "*"+FIXTURE_EXT))
+	if len(fixtures) != 1 {
+		t.Fatalf("Expected 1 fixture, got %v", fixtures)
+	}
+	data, _ := os.ReadFile(fixtures[0])
+	if strings.Contains(string(data), "secret-token") || !strings.Contains(string(data), REDACTED) {
+		t.Errorf("Expected Authorization header to be redacted:\n%s", data)
+	}
+
+	replayClient, _ := NewFixtureClient("", dir, nil)
+
+	res, err = replayClient.Get(server.URL + "/users/octocat/events?page=1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	body, _ = io.ReadAll(res.Body)
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK || string(body) != `[{"type":"PushEvent"}]` {
+		t.Errorf("Unexpected replayed response: %d %q", res.StatusCode, body)
+	}
+	if res.Header.Get("X-RateLimit-Remaining") != "59" {
+		t.Errorf("Expected replayed headers, got %v", res.Header)
+	}
+
+	if _, err := replayClient.Get(server.URL + "/users/octocat/events?page=2"); err == nil {
+		t.Error("Expected error for request without a fixture")
+	}
+}
+
+func TestNewFixtureClientConflict(t *testing.T) {
+	if _, err := NewFixtureClient(t.TempDir(), t.TempDir(), nil); err == nil {
+		t.Error("Expected error when recording and replaying at once")
+	}
+}
+
+func TestFetchUserEventsReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	// Record canned GitHub responses, then run the real client against them
+	recorder := &http.Client{Transport: RecordingTransport{
+		Dir: dir,
+		Next: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body := `[{"id":"1","type":"PushEvent","repo":{"name":"octocat/hello"}}]`
+			status := http.StatusOK
+			if req.URL.Path == "/users/ghost/events" {
+				body, status = `{"message":"Not Found"}`, http.StatusNotFound
+			}
+			return &http.Response{
+				StatusCode: status,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}),
+	}}
+
+	if _, err := New(recorder).FetchUserEvents(context.Background(), "octocat", "1", "30"); err != nil {
+		t.Fatalf("Unexpected error while recording: %v", err)
+	}
+	New(recorder).FetchUserEvents(context.Background(), "ghost", "1", "30")
+
+	replay, _ := NewFixtureClient("", dir, nil)
+
+	activities, err := New(replay).FetchUserEvents(context.Background(), "octocat", "1", "30")
+	if err != nil || len(activities) != 1 || activities[0].Repo.Name != "octocat/hello" {
+		t.Errorf("Unexpected replayed activities: %v, %v", activities, err)
+	}
+
+	if _, err := New(replay).FetchUserEvents(context.Background(), "ghost", "1", "30"); err == nil {
+		t.Error("Expected error for replayed 404")
+	}
+
+	if _, err := New(replay).FetchUserEvents(context.Background(), "octocat", "2", "30"); err == nil {
+		t.Error("Expected error for a page that was never recorded")
+	}
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// NormalizeHost reduces host to a lowercase hostname, stripping any scheme,
+// path or trailing slash, so "https://GHE.example.com/" becomes
+// "ghe.example.com". An empty host and api.github.com are github.com.
+func NormalizeHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if _, rest, ok := strings.Cut(host, "://"); ok {
+		host = rest
+	}
+	host, _, _ = strings.Cut(host, "/")
+
+	if host == "" || host == API_HOST {
+		return DEFAULT_HOST
+	}
+	return host
+}
+
+// IsEnterprise reports whether host is a GitHub Enterprise host rather than
+// github.com.
+func IsEnterprise(host string) bool {
+	return NormalizeHost(host) != DEFAULT_HOST
+}
+
+// APIURL returns the REST API base URL for host. GitHub Enterprise Server
+// serves it under /api/v3 of its own hostname, while github.com and
+// GitHub Enterprise Cloud with data residency (*.ghe.com) use an api.
+// subdomain.
+func APIURL(host string) string {
+	host = NormalizeHost(host)
+
+	switch {
+	case host == DEFAULT_HOST:
+		return DEFAULT_API_URL
+	case strings.HasSuffix(host, "."+GHE_CLOUD_DOMAIN):
+		return "https://api." + host
+	default:
+		return "https://" + host + ENTERPRISE_API_PATH
+	}
+}
+
+// NewCATransport returns a copy of http.DefaultTransport that trusts the PEM
+// certificates in caFile on top of the system roots, for servers signed by a
+// private certificate authority.
+func NewCATransport(caFile string) (*http.Transport, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if !roots.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", caFile)
+	}
+
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("default transport isn't an *http.Transport")
+	}
+
+	transport := base.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.RootCAs = roots
+	return transport, nil
+}
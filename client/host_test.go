@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalizeHost(t *testing.T) {
+	tests := []struct {
+		host     string
+		expected string
+	}{
+		{host: "", expected: "github.com"},
+		{host: "github.com", expected: "github.com"},
+		{host: "api.github.com", expected: "github.com"},
+		{host: "GHE.example.com", expected: "ghe.example.com"},
+		{host: "https://ghe.example.com/", expected: "ghe.example.com"},
+		{host: "https://ghe.example.com/api/v3", expected: "ghe.example.com"},
+		{host: "ghe.example.com:8443", expected: "ghe.example.com:8443"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeHost(tt.host); got != tt.expected {
+			t.Errorf("NormalizeHost(%q) = %q, expected %q", tt.host, got, tt.expected)
+		}
+	}
+}
+
+func TestAPIURL(t *testing.T) {
+	tests := []struct {
+		host     string
+		expected string
+	}{
+		{host: "", expected: "https://api.github.com"},
+		{host: "github.com", expected: "https://api.github.com"},
+		{host: "ghe.example.com", expected: "https://ghe.example.com/api/v3"},
+		{host: "https://ghe.example.com/", expected: "https://ghe.example.com/api/v3"},
+		{host: "octo-corp.ghe.com", expected: "https://api.octo-corp.ghe.com"},
+	}
+
+	for _, tt := range tests {
+		if got := APIURL(tt.host); got != tt.expected {
+			t.Errorf("APIURL(%q) = %q, expected %q", tt.host, got, tt.expected)
+		}
+	}
+
+	if IsEnterprise("api.github.com") || !IsEnterprise("ghe.example.com") {
+		t.Error("Expected only non-github.com hosts to be enterprise hosts")
+	}
+}
+
+func TestNewCATransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/users/octocat/events" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`[{"type":"WatchEvent"}]`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	bundle := filepath.Join(dir, "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundle, cert, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without the bundle the self-signed server isn't trusted
+	untrusted := Client{HTTPClient: &http.Client{}, BaseURL: server.URL + ENTERPRISE_API_PATH}
+	if _, err := untrusted.FetchUserEvents(context.Background(), "octocat", "1", "30"); err == nil {
+		t.Error("Expected a certificate error without the CA bundle")
+	}
+
+	transport, err := NewCATransport(bundle)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	trusted := Client{HTTPClient: &http.Client{Transport: transport}, BaseURL: server.URL + ENTERPRISE_API_PATH}
+	activities, err := trusted.FetchUserEvents(context.Background(), "octocat", "1", "30")
+	if err != nil || len(activities) != 1 {
+		t.Errorf("Expected 1 event through the CA bundle, got %v, %v", activities, err)
+	}
+
+	empty := filepath.Join(dir, "empty.pem")
+	os.WriteFile(empty, []byte("not a certificate"), 0o600)
+	if _, err := NewCATransport(empty); err == nil {
+		t.Error("Expected error for a bundle without certificates")
+	}
+	if _, err := NewCATransport(filepath.Join(dir, "missing.pem")); err == nil {
+		t.Error("Expected error for a missing bundle")
+	}
+}
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy decides how often a request that failed for a transient reason
+// is repeated and how long the client waits in between. The zero value sends
+// every request exactly once.
+type RetryPolicy struct {
+	// MaxAttempts caps the number of attempts, the first one included.
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry; it doubles on every
+	// further retry up to MaxDelay, or MAX_RETRY_DELAY when that is zero.
+	BaseDelay time.Duration
+	// MaxDelay also caps the server's Retry-After; a request asked to wait
+	// longer is not retried.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used by New.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: DEFAULT_MAX_ATTEMPTS,
+		BaseDelay:   DEFAULT_RETRY_BASE_DELAY,
+		MaxDelay:    DEFAULT_RETRY_MAX_DELAY,
+	}
+}
+
+// Backoff returns the jittered wait before the retry that follows the given
+// failed attempt: a random duration between half and all of
+// BaseDelay*2^(attempt-1), capped at MaxDelay.
+func (policy RetryPolicy) Backoff(attempt int) time.Duration {
+	delay := policy.BaseDelay
+	for i := 1; i < attempt && delay < MAX_RETRY_DELAY; i++ {
+		delay *= 2
+	}
+	if policy.MaxDelay > 0 {
+		delay = min(delay, policy.MaxDelay)
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// maxDelay is the longest the client waits before a retry, whether the wait
+// comes from Backoff or from the server's Retry-After header.
+func (policy RetryPolicy) maxDelay() time.Duration {
+	if policy.MaxDelay > 0 {
+		return policy.MaxDelay
+	}
+	return MAX_RETRY_DELAY
+}
+
+// get sends a GET request for url and retries it according to client.Retry
+// while it fails with a network error, a 5xx status or a secondary rate
+// limit. The last response or error is returned once the attempts run out,
+// or right away when the server's Retry-After asks for a longer wait than the
+// policy allows.
+func (client Client) get(ctx context.Context, url string) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+		if client.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+client.Token)
+		}
+
+		res, err := client.HTTPClient.Do(req)
+		if err != nil && ctx.Err() != nil {
+			return nil, context.Cause(ctx)
+		}
+		if attempt >= client.Retry.MaxAttempts || !retryable(res, err) {
+			return res, err
+		}
+
+		delay := client.Retry.Backoff(attempt)
+		if res != nil {
+			if wait, ok := retryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+				if wait > client.Retry.maxDelay() {
+					return res, nil
+				}
+				delay = wait
+			}
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryable reports whether a request that ended with res or err is worth
+// sending again. A primary rate limit, told apart by an exhausted
+// X-RateLimit-Remaining, is not: it only lifts when the hourly quota resets.
+func retryable(res *http.Response, err error) bool {
+	if err != nil {
+		return networkError(err)
+	}
+	if res.Header.Get("X-RateLimit-Remaining") == "0" {
+		return false
+	}
+
+	switch {
+	case res.StatusCode >= 500:
+		return true
+	case res.StatusCode == http.StatusTooManyRequests:
+		return true
+	case res.StatusCode == http.StatusForbidden:
+		return res.Header.Get("Retry-After") != "" || secondaryRateLimited(res)
+	}
+
+	return false
+}
+
+// networkError reports whether err, as returned by http.Client.Do, comes
+// from the connection rather than from a transport that refused the request,
+// such as a replay with no recorded response.
+func networkError(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// secondaryRateLimited reports whether a 403 response comes from GitHub's
+// secondary rate limit. The body is read and put back so the caller can
+// still consume it.
+func secondaryRateLimited(res *http.Response) bool {
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(string(body)), SECONDARY_RATE_LIMIT_MESSAGE)
+}
+
+// retryAfter parses a Retry-After header, given either in seconds or as an
+// HTTP date, into the wait from now.
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(date.Sub(now), 0), true
+}
+
+// sleep waits for delay or until ctx is done, whichever comes first.
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	case <-timer.C:
+		return nil
+	}
+}
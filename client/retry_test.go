@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchUserEventsRetry(t *testing.T) {
+	tests := []struct {
+		name             string
+		failures         int
+		failStatus       int
+		failBody         string
+		retryAfter       string
+		remaining        string
+		maxAttempts      int
+		expectError      bool
+		expectedRequests int32
+	}{
+		{
+			name:             "Bad gateway then success",
+			failures:         2,
+			failStatus:       http.StatusBadGateway,
+			maxAttempts:      3,
+			expectedRequests: 3,
+		},
+		{
+			name:             "Attempts exhausted",
+			failures:         5,
+			failStatus:       http.StatusServiceUnavailable,
+			maxAttempts:      3,
+			expectError:      true,
+			expectedRequests: 3,
+		},
+		{
+			name:             "Secondary rate limit",
+			failures:         1,
+			failStatus:       http.StatusForbidden,
+			failBody:         `{"message":"You have exceeded a secondary rate limit."}`,
+			maxAttempts:      3,
+			expectedRequests: 2,
+		},
+		{
+			name:             "Too many requests with Retry-After",
+			failures:         1,
+			failStatus:       http.StatusTooManyRequests,
+			retryAfter:       "0",
+			maxAttempts:      3,
+			expectedRequests: 2,
+		},
+		{
+			name:             "Primary rate limit is not retried",
+			failures:         1,
+			failStatus:       http.StatusForbidden,
+			failBody:         `{"message":"API rate limit exceeded"}`,
+			maxAttempts:      3,
+			expectError:      true,
+			expectedRequests: 1,
+		},
+		{
+			name:             "Primary rate limit with too many requests is not retried",
+			failures:         1,
+			failStatus:       http.StatusTooManyRequests,
+			retryAfter:       "0",
+			remaining:        "0",
+			maxAttempts:      3,
+			expectError:      true,
+			expectedRequests: 1,
+		},
+		{
+			name:             "Retry-After beyond max delay is not waited for",
+			failures:         1,
+			failStatus:       http.StatusServiceUnavailable,
+			retryAfter:       "3600",
+			maxAttempts:      3,
+			expectError:      true,
+			expectedRequests: 1,
+		},
+		{
+			name:             "Not found is not retried",
+			failures:         1,
+			failStatus:       http.StatusNotFound,
+			maxAttempts:      3,
+			expectError:      true,
+			expectedRequests: 1,
+		},
+		{
+			name:             "Zero policy sends once",
+			failures:         1,
+			failStatus:       http.StatusInternalServerError,
+			expectError:      true,
+			expectedRequests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if int(requests.Add(1)) <= tt.failures {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					if tt.remaining != "" {
+						w.Header().Set("X-RateLimit-Remaining", tt.remaining)
+					}
+					w.WriteHeader(tt.failStatus)
+					w.Write([]byte(tt.failBody))
+					return
+				}
+				w.Write([]byte(`[{"type":"PushEvent"}]`))
+			}))
+			defer server.Close()
+
+			client := Client{
+				HTTPClient: server.Client(),
+				BaseURL:    server.URL,
+				Retry:      RetryPolicy{MaxAttempts: tt.maxAttempts, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond},
+			}
+			result, err := client.FetchUserEvents(context.Background(), "octocat", "1", "30")
+
+			if tt.expectError && err == nil {
+				t.Error("Expected an error, got none")
+			}
+			if !tt.expectError {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if len(result) != 1 {
+					t.Errorf("Expected 1 event, got %d", len(result))
+				}
+			}
+			if got := requests.Load(); got != tt.expectedRequests {
+				t.Errorf("Expected %d requests, got %d", tt.expectedRequests, got)
+			}
+		})
+	}
+}
+
+func TestFetchUserEventsRetryNetworkError(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			// Drop the connection without answering
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := Client{
+		HTTPClient: server.Client(),
+		BaseURL:    server.URL,
+		Retry:      RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond},
+	}
+	if _, err := client.FetchUserEvents(context.Background(), "octocat", "1", "30"); err != nil {
+		t.Fatalf("Expected the retry to succeed, got %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected 2 requests, got %d", got)
+	}
+}
+
+func TestFetchUserEventsRetryCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := Client{
+		HTTPClient: server.Client(),
+		BaseURL:    server.URL,
+		Retry:      RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour},
+	}
+
+	timeout := errors.New("timed out")
+	ctx, cancel := context.WithTimeoutCause(context.Background(), 50*time.Millisecond, timeout)
+	defer cancel()
+
+	if _, err := client.FetchUserEvents(ctx, "octocat", "1", "30"); !errors.Is(err, timeout) {
+		t.Errorf("Expected the context cause while backing off, got %v", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		attempt int
+		ceiling time.Duration
+	}{
+		{attempt: 1, ceiling: 100 * time.Millisecond},
+		{attempt: 2, ceiling: 200 * time.Millisecond},
+		{attempt: 3, ceiling: 400 * time.Millisecond},
+		{attempt: 5, ceiling: time.Second},
+		{attempt: 50, ceiling: time.Second},
+	}
+
+	for _, tt := range tests {
+		for range 20 {
+			delay := policy.Backoff(tt.attempt)
+			if delay < tt.ceiling/2 || delay > tt.ceiling {
+				t.Errorf("Backoff(%d) = %s, expected between %s and %s", tt.attempt, delay, tt.ceiling/2, tt.ceiling)
+			}
+		}
+	}
+
+	if delay := (RetryPolicy{}).Backoff(1); delay != 0 {
+		t.Errorf("Expected no delay for the zero policy, got %s", delay)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "Seconds", value: "7", expected: 7 * time.Second, ok: true},
+		{name: "HTTP date", value: "Mon, 01 Jan 2024 12:00:30 GMT", expected: 30 * time.Second, ok: true},
+		{name: "Date in the past", value: "Mon, 01 Jan 2024 11:00:00 GMT", expected: 0, ok: true},
+		{name: "Missing", value: "", ok: false},
+		{name: "Garbage", value: "soon", ok: false},
+		{name: "Negative", value: "-3", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, ok := retryAfter(tt.value, now)
+			if ok != tt.ok || delay != tt.expected {
+				t.Errorf("retryAfter(%q) = %s, %v; expected %s, %v", tt.value, delay, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
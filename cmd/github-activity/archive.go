@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+// eventArchive is an append-only store of events on disk. Each user or
+// organization has a directory of JSON lines segments, one per month of event
+// creation.
+type eventArchive struct {
+	dir string
+}
+
+func defaultArchiveDir() string {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, APP_NAME, ARCHIVE_DIR_NAME)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".", APP_NAME, ARCHIVE_DIR_NAME)
+	}
+	return filepath.Join(home, ".local", "share", APP_NAME, ARCHIVE_DIR_NAME)
+}
+
+// users and orgs are sibling archives, so an organization never mixes with a
+// user of the same name and no login clashes with either directory.
+func (archive eventArchive) users() eventArchive {
+	return eventArchive{dir: filepath.Join(archive.dir, ARCHIVE_USERS_DIR)}
+}
+
+func (archive eventArchive) orgs() eventArchive {
+	return eventArchive{dir: filepath.Join(archive.dir, ARCHIVE_ORGS_DIR)}
+}
+
+func (archive eventArchive) userDir(username string) (string, error) {
+	if username == "" || username != filepath.Base(username) || strings.HasPrefix(username, ".") {
+		return "", fmt.Errorf("invalid username '%s'", username)
+	}
+	return filepath.Join(archive.dir, strings.ToLower(username)), nil
+}
+
+func (archive eventArchive) load(username string) ([]events.Event, error) {
+	dir, err := archive.userDir(username)
+	if err != nil {
+		return []events.Event{}, err
+	}
+
+	segments, err := filepath.Glob(filepath.Join(dir, "*"+ARCHIVE_SEGMENT_EXT))
+	if err != nil {
+		return []events.Event{}, err
+	}
+
+	var lists [][]events.Event
+	for _, segment := range segments {
+		segmentEvents, err := readArchiveSegment(segment)
+		if err != nil {
+			return []events.Event{}, err
+		}
+		lists = append(lists, segmentEvents)
+	}
+
+	return events.Merge(lists...), nil
+}
+
+// append writes the events that aren't archived yet and returns how many
+// were added.
+func (archive eventArchive) append(username string, activities []events.Event) (int, error) {
+	archived, err := archive.load(username)
+	if err != nil {
+		return 0, err
+	}
+
+	seen := map[string]bool{}
+	for _, event := range archived {
+		seen[event.ID] = true
+	}
+
+	segments := map[string][]events.Event{}
+	for _, event := range activities {
+		if event.ID == "" || seen[event.ID] {
+			continue
+		}
+		seen[event.ID] = true
+
+		segment := ARCHIVE_UNDATED_SEGMENT
+		if !event.CreatedAt.IsZero() {
+			segment = event.CreatedAt.UTC().Format(ARCHIVE_SEGMENT_FORMAT)
+		}
+		segments[segment] = append(segments[segment], event)
+	}
+
+	if len(segments) < 1 {
+		return 0, nil
+	}
+
+	dir, err := archive.userDir(username)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return 0, err
+	}
+
+	added := 0
+	for segment, segmentEvents := range segments {
+		// Write oldest first so each segment reads in chronological order
+		slices.Reverse(segmentEvents)
+		if err := appendArchiveSegment(filepath.Join(dir, segment+ARCHIVE_SEGMENT_EXT), segmentEvents); err != nil {
+			return added, err
+		}
+		added += len(segmentEvents)
+	}
+
+	return added, nil
+}
+
+func readArchiveSegment(path string) ([]events.Event, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var activities []events.Event
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), ARCHIVE_MAX_LINE_SIZE)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+
+		var event events.Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, line, err)
+		}
+		activities = append(activities, event)
+	}
+
+	return activities, scanner.Err()
+}
+
+func appendArchiveSegment(path string, activities []events.Event) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(file)
+	for _, event := range activities {
+		if err := encoder.Encode(event); err != nil {
+			file.Close()
+			return err
+		}
+	}
+
+	return file.Close()
+}
+
+// userActivities loads one page of a user's events from the API, from the
+// archive when running offline, or every event of an --input file.
+func userActivities(ctx context.Context, username string, opts cliOptions) ([]events.Event, error) {
+	if opts.input != "" {
+		return readEventsInput(opts.input, os.Stdin)
+	}
+	if !opts.offline {
+		return opts.client.FetchUserEvents(ctx, username, opts.page, opts.perPage)
+	}
+
+	archive, name := opts.archivedFeed(username)
+	activities, err := archive.load(name)
+	if err != nil {
+		return []events.Event{}, err
+	}
+
+	page, _ := strconv.Atoi(opts.page)
+	perPage, _ := strconv.Atoi(opts.perPage)
+	start := min(max(page-1, 0)*perPage, len(activities))
+	end := min(start+perPage, len(activities))
+
+	return activities[start:end], nil
+}
+
+// allUserActivities loads every event the API exposes for a user, the whole
+// archived history when running offline, or every event of an --input file.
+func allUserActivities(ctx context.Context, username string, opts cliOptions) ([]events.Event, error) {
+	if opts.input != "" {
+		return readEventsInput(opts.input, os.Stdin)
+	}
+	if opts.offline {
+		archive, name := opts.archivedFeed(username)
+		return archive.load(name)
+	}
+	return opts.client.FetchAllUserEvents(ctx, username)
+}
+
+// archivedFeed picks the archive holding username's events, or those of the
+// --org organization when no username is given.
+func (opts cliOptions) archivedFeed(username string) (eventArchive, string) {
+	if username == "" && opts.org != "" {
+		return opts.archive().orgs(), opts.org
+	}
+	return opts.archive().users(), username
+}
+
+func runSync(ctx context.Context, args []string) error {
+	var usernames []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		usernames = append(usernames, arg)
+	}
+
+	opts, err := parseOptions(args[len(usernames):])
+	if err != nil {
+		return usageError("parsing %v", err)
+	}
+
+	ctx, cancel := opts.fetchContext(ctx)
+	defer cancel()
+
+	if path, ok := argValue(args, "--team"); ok {
+		roster, err := opts.teamRoster(path)
+		if err != nil {
+			return fmt.Errorf("loading team roster: %w", err)
+		}
+		usernames = append(usernames, roster.usernames()...)
+	}
+
+	var orgs []string
+	if opts.org != "" {
+		orgs = strings.Split(opts.org, ",")
+	}
+
+	if len(usernames) < 1 && len(orgs) < 1 {
+		return errUsage
+	}
+	if !opts.online() {
+		return usageError("parsing sync: --offline and --input can't be used with sync")
+	}
+
+	archive := opts.archive()
+	fmt.Fprintf(os.Stderr, "Syncing %d users and %d orgs into %s...\n", len(usernames), len(orgs), archive.dir)
+	fmt.Fprintln(os.Stderr, opts.credentials)
+
+	var errs []error
+	store := func(archive eventArchive, results []userFetchResult, kind string) {
+		for _, result := range results {
+			if result.err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", opts.qualify(result.username), result.err))
+				continue
+			}
+
+			added, err := archive.append(result.username, result.activities)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", opts.qualify(result.username), err))
+				continue
+			}
+			fmt.Printf("  - Archived %d new events for %s '%s'\n", added, kind, opts.qualify(result.username))
+		}
+	}
+
+	store(archive.users(), fetchUsers(usernames, func(username string) ([]events.Event, error) {
+		return opts.client.FetchAllUserEvents(ctx, username)
+	}), "user")
+	store(archive.orgs(), fetchUsers(orgs, func(org string) ([]events.Event, error) {
+		return opts.client.FetchAllOrgEvents(ctx, org)
+	}), "org")
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("syncing activity: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+func TestEventArchive(t *testing.T) {
+	archive := eventArchive{dir: t.TempDir()}
+
+	first := []events.Event{
+		eventFromJSON(t, `{"id":"3","type":"PushEvent","repo":{"name":"a/b"},"created_at":"2025-03-02T12:00:00Z"}`),
+		eventFromJSON(t, `{"id":"2","type":"WatchEvent","repo":{"name":"a/b"},"created_at":"2025-02-27T12:00:00Z"}`),
+		eventFromJSON(t, `{"id":"1","type":"PushEvent","repo":{"name":"a/b"},"created_at":"2025-02-26T12:00:00Z"}`),
+	}
+
+	added, err := archive.append("Octocat", first)
+	if err != nil || added != 3 {
+		t.Fatalf("Expected 3 events added, got %d and %v", added, err)
+	}
+
+	second := []events.Event{
+		eventFromJSON(t, `{"id":"4","type":"IssuesEvent","repo":{"name":"a/b"},"created_at":"2025-03-03T12:00:00Z"}`),
+		first[0],
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"a/b"}}`),
+	}
+
+	added, err = archive.append("octocat", second)
+	if err != nil || added != 1 {
+		t.Fatalf("Expected 1 new event added, got %d and %v", added, err)
+	}
+
+	segments, _ := filepath.Glob(filepath.Join(archive.dir, "octocat", "*"+ARCHIVE_SEGMENT_EXT))
+	if len(segments) != 2 {
+		t.Errorf("Expected one segment per month, got %v", segments)
+	}
+
+	activities, err := archive.load("octocat")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedIDs := []string{"4", "3", "2", "1"}
+	if len(activities) != len(expectedIDs) {
+		t.Fatalf("Expected %d archived events, got %d", len(expectedIDs), len(activities))
+	}
+	for i, id := range expectedIDs {
+		if activities[i].ID != id {
+			t.Errorf("Expected event %d to have id %s, got %s", i, id, activities[i].ID)
+		}
+	}
+}
+
+func TestEventArchiveEmptyAndInvalid(t *testing.T) {
+	archive := eventArchive{dir: t.TempDir()}
+
+	activities, err := archive.load("nobody")
+	if err != nil || len(activities) != 0 {
+		t.Errorf("Expected empty archive, got %d events and %v", len(activities), err)
+	}
+
+	for _, username := range []string{"", "../etc", "a/b", ".hidden"} {
+		if _, err := archive.load(username); err == nil {
+			t.Errorf("Expected error for username %q", username)
+		}
+	}
+
+	dir := filepath.Join(archive.dir, "broken")
+	os.MkdirAll(dir, 0o700)
+	os.WriteFile(filepath.Join(dir, "2025-03"+ARCHIVE_SEGMENT_EXT), []byte("{not json}\n"), 0o600)
+	if _, err := archive.load("broken"); err == nil {
+		t.Error("Expected error for corrupt segment")
+	}
+}
+
+func TestUserActivitiesOffline(t *testing.T) {
+	archive := eventArchive{dir: t.TempDir()}
+
+	var activities []events.Event
+	for _, day := range []string{"05", "04", "03", "02", "01"} {
+		activities = append(activities, eventFromJSON(t, `{"id":"`+day+`","type":"PushEvent","created_at":"2025-03-`+day+`T12:00:00Z"}`))
+	}
+	if _, err := archive.users().append("octocat", activities); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := archive.orgs().append("acme", activities[:1]); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	opts, _ := parseOptions([]string{"--offline", "--archive-dir", archive.dir, "-p", "2", "-n", "2"})
+
+	page, err := userActivities(context.Background(), "octocat", opts)
+	if err != nil || len(page) != 2 || page[0].ID != "03" || page[1].ID != "02" {
+		t.Errorf("Unexpected second page: %v, %v", page, err)
+	}
+
+	opts.page = "4"
+	if page, _ := userActivities(context.Background(), "octocat", opts); len(page) != 0 {
+		t.Errorf("Expected empty page past the end, got %d events", len(page))
+	}
+
+	all, err := allUserActivities(context.Background(), "octocat", opts)
+	if err != nil || len(all) != 5 {
+		t.Errorf("Expected the whole archive, got %d events and %v", len(all), err)
+	}
+
+	opts.org = "acme"
+	if all, _ := allUserActivities(context.Background(), "", opts); len(all) != 1 || all[0].ID != "05" {
+		t.Errorf("Expected the organization's archive without a username, got %v", all)
+	}
+	if all, _ := allUserActivities(context.Background(), "octocat", opts); len(all) != 5 {
+		t.Errorf("Expected the username to win over --org, got %d events", len(all))
+	}
+}
+
+func TestRunSync(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/alice/events":
+			w.Write([]byte(`[{"id":"1","type":"PushEvent","created_at":"2025-03-02T12:00:00Z"}]`))
+		case "/orgs/acme/events":
+			w.Write([]byte(`[{"id":"2","type":"ReleaseEvent","created_at":"2025-03-02T12:00:00Z"},{"id":"3","type":"WatchEvent","created_at":"2025-03-01T12:00:00Z"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	output, err := captureStdout(func() error {
+		return runSync(context.Background(), []string{
+			"alice", "--org", "acme",
+			"--archive-dir", dir, "--api-url", server.URL, "--config", filepath.Join(dir, "missing.json"),
+		})
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "1 new events for user 'alice'") || !strings.Contains(output, "2 new events for org 'acme'") {
+		t.Errorf("Expected a line per user and org, got %q", output)
+	}
+
+	archive := eventArchive{dir: dir}
+	if activities, _ := archive.users().load("alice"); len(activities) != 1 {
+		t.Errorf("Expected 1 archived event for alice, got %d", len(activities))
+	}
+	if activities, _ := archive.orgs().load("acme"); len(activities) != 2 {
+		t.Errorf("Expected 2 archived events for acme, got %d", len(activities))
+	}
+	if activities, _ := archive.users().load("acme"); len(activities) != 0 {
+		t.Errorf("Expected the organization kept apart from users, got %d events", len(activities))
+	}
+
+	if err := runSync(context.Background(), []string{"--archive-dir", dir}); err != errUsage {
+		t.Errorf("Expected usage error without users or orgs, got %v", err)
+	}
+}
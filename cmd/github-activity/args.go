@@ -0,0 +1,328 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/client"
+	"github.com/dmitriy-zverev/github-activity/filter"
+	"github.com/dmitriy-zverev/github-activity/render"
+)
+
+type cliOptions struct {
+	page        string
+	perPage     string
+	filters     filter.Options
+	verbose     bool
+	interactive bool
+	output      string
+	groupBy     string
+	collapse    bool
+	location    *time.Location
+	offline     bool
+	archiveDir  string
+	org         string
+	input       string
+	timeout     time.Duration
+	host        string
+	credentials credentials
+	client      client.Client
+	configPath  string
+	profile     string
+	config      configProfile
+}
+
+func parseOptions(args []string) (cliOptions, error) {
+	opts := cliOptions{
+		page:    DEFAULT_PAGE_NUM,
+		perPage: DEFAULT_PER_PAGE_EVENTS,
+		filters: filter.Options{
+			Type:        DEFAULT_FILTER_TYPE,
+			Bots:        DEFAULT_BOT_FILTER,
+			BotDenylist: filter.DEFAULT_BOT_DENYLIST,
+		},
+		output:      DEFAULT_OUTPUT_FORMAT,
+		groupBy:     DEFAULT_GROUP_BY,
+		collapse:    !slices.Contains(args, "--no-collapse"),
+		verbose:     slices.Contains(args, "-v") || slices.Contains(args, "--verbose"),
+		interactive: slices.Contains(args, "--interactive"),
+		location:    time.Local,
+		offline:     slices.Contains(args, "--offline"),
+		archiveDir:  defaultArchiveDir(),
+		timeout:     DEFAULT_TIMEOUT,
+		configPath:  defaultConfigPath(),
+	}
+
+	if value, ok := argValue(args, "--config"); ok {
+		opts.configPath = value
+	}
+	if value, ok := argValue(args, "--profile"); ok {
+		opts.profile = value
+	}
+	config, err := loadConfig(opts.configPath)
+	if err != nil {
+		return opts, fmt.Errorf("config: %v", err)
+	}
+	profile, err := config.profile(opts.profile)
+	if err != nil {
+		return opts, fmt.Errorf("config: %v", err)
+	}
+	if err := profile.apply(&opts); err != nil {
+		return opts, fmt.Errorf("config: %v", err)
+	}
+
+	if value, ok := argValue(args, "-p"); ok {
+		if _, err := strconv.Atoi(value); err != nil {
+			return opts, fmt.Errorf("page number: %v is not a number", value)
+		}
+		opts.page = value
+	}
+
+	if value, ok := argValue(args, "-n"); ok {
+		if _, err := strconv.Atoi(value); err != nil {
+			return opts, fmt.Errorf("per page events number: %v is not a number", value)
+		}
+		opts.perPage = value
+	}
+
+	if value, ok := argValue(args, "-f"); ok {
+		opts.filters.Type = value
+	}
+
+	if slices.Contains(args, "--no-bots") && slices.Contains(args, "--only-bots") {
+		return opts, errors.New("bot filter: --no-bots and --only-bots are mutually exclusive")
+	}
+	if slices.Contains(args, "--all-bots") {
+		opts.filters.Bots = filter.BOT_FILTER_NONE
+	}
+	if slices.Contains(args, "--no-bots") {
+		opts.filters.Bots = filter.BOT_FILTER_EXCLUDE
+	}
+	if slices.Contains(args, "--only-bots") {
+		opts.filters.Bots = filter.BOT_FILTER_ONLY
+	}
+	if value, ok := argValue(args, "--bot-denylist"); ok {
+		opts.filters.BotDenylist = append(slices.Clone(opts.filters.BotDenylist), strings.Split(value, ",")...)
+	}
+
+	if value, ok := argValue(args, "--grep"); ok {
+		re, err := filter.CompilePattern(
+			value,
+			slices.Contains(args, "--regex"),
+			slices.Contains(args, "--ignore-case"),
+		)
+		if err != nil {
+			return opts, fmt.Errorf("grep pattern: %v", err)
+		}
+		opts.filters.Grep = re
+		opts.filters.GrepPattern = value
+	}
+
+	if value, ok := argValue(args, "-o"); ok {
+		opts.output = value
+	}
+	if value, ok := argValue(args, "--output"); ok {
+		opts.output = value
+	}
+	if err := render.ValidateOutputFormat(opts.output); err != nil {
+		return opts, fmt.Errorf("output format: %v", err)
+	}
+
+	if value, ok := argValue(args, "--group-by"); ok {
+		opts.groupBy = value
+	}
+	if err := render.ValidateGroupBy(opts.groupBy); err != nil {
+		return opts, fmt.Errorf("group: %v", err)
+	}
+
+	if value, ok := argValue(args, "--tz"); ok {
+		location, err := time.LoadLocation(value)
+		if err != nil {
+			return opts, fmt.Errorf("timezone: %v", err)
+		}
+		opts.location = location
+	}
+
+	if value, ok := argValue(args, "--archive-dir"); ok {
+		opts.archiveDir = value
+	}
+	if value, ok := argValue(args, "--org"); ok {
+		opts.org = value
+	}
+
+	if value, ok := argValue(args, "--input"); ok {
+		opts.input = value
+	}
+	if opts.input != "" && opts.offline {
+		return opts, errors.New("input: --input and --offline are mutually exclusive")
+	}
+
+	if value, ok := argValue(args, "--timeout"); ok {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout < 0 {
+			return opts, fmt.Errorf("timeout: %v is not a duration like 30s or 2m", value)
+		}
+		opts.timeout = timeout
+	}
+
+	gh, err := loadGHHosts(ghHostsPath())
+	if err != nil {
+		return opts, fmt.Errorf("gh CLI config: %v", err)
+	}
+
+	opts.host = opts.config.Host
+	if value := os.Getenv(HOST_ENV); value != "" {
+		opts.host = value
+	}
+	if value, ok := argValue(args, "--host"); ok {
+		opts.host = value
+	}
+	if opts.host == "" {
+		opts.host = gh.defaultHost()
+	}
+	opts.host = client.NormalizeHost(opts.host)
+	opts.credentials = resolveCredentials(opts.host, opts.config.TokenEnv, os.Getenv, gh)
+
+	var transport http.RoundTripper
+	if value, ok := argValue(args, "--ca-bundle"); ok {
+		caTransport, err := client.NewCATransport(value)
+		if err != nil {
+			return opts, fmt.Errorf("CA bundle: %v", err)
+		}
+		transport = caTransport
+	}
+
+	recordDir, _ := argValue(args, "--record")
+	replayDir, _ := argValue(args, "--replay")
+	httpClient, err := client.NewFixtureClient(recordDir, replayDir, transport)
+	if err != nil {
+		return opts, fmt.Errorf("fixtures: %v", err)
+	}
+	opts.client = client.New(httpClient)
+	opts.client.BaseURL = client.APIURL(opts.host)
+	if value, ok := argValue(args, "--api-url"); ok {
+		opts.client.BaseURL = value
+		// The token belongs to the host, so it only goes to another URL
+		// when the user asks for that
+		if !slices.Contains(args, "--api-url-token") {
+			opts.credentials = credentials{host: value}
+		}
+	}
+	opts.client.Token = opts.credentials.token
+
+	if opts.config.MaxAttempts != 0 {
+		opts.client.Retry.MaxAttempts = opts.config.MaxAttempts
+	}
+	if opts.config.RetryDelay != "" {
+		// Validated when the config was applied
+		opts.client.Retry.BaseDelay, _ = time.ParseDuration(opts.config.RetryDelay)
+	}
+	if value, ok := argValue(args, "--max-attempts"); ok {
+		attempts, err := strconv.Atoi(value)
+		if err != nil || attempts < 1 {
+			return opts, fmt.Errorf("max attempts: %v is not a positive number", value)
+		}
+		opts.client.Retry.MaxAttempts = attempts
+	}
+	if value, ok := argValue(args, "--retry-delay"); ok {
+		delay, err := time.ParseDuration(value)
+		if err != nil || delay < 0 {
+			return opts, fmt.Errorf("retry delay: %v is not a duration like 500ms or 2s", value)
+		}
+		opts.client.Retry.BaseDelay = delay
+	}
+
+	return opts, nil
+}
+
+// online reports whether events come from the GitHub API rather than the
+// archive or an input file.
+func (opts cliOptions) online() bool {
+	return !opts.offline && opts.input == ""
+}
+
+// fetchContext bounds ctx by --timeout. A zero timeout waits for as long as
+// the API takes.
+func (opts cliOptions) fetchContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if opts.timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeoutCause(ctx, opts.timeout, timeoutError{timeout: opts.timeout})
+}
+
+// enterpriseHost is the host events come from when it isn't github.com, and
+// empty otherwise.
+func (opts cliOptions) enterpriseHost() string {
+	if client.IsEnterprise(opts.host) {
+		return opts.host
+	}
+	return ""
+}
+
+// qualify prefixes username with its host when that isn't github.com, so
+// output from several hosts can't be mixed up.
+func (opts cliOptions) qualify(username string) string {
+	if host := opts.enterpriseHost(); host != "" {
+		return host + "/" + username
+	}
+	return username
+}
+
+// archive keeps the events of each enterprise host in a directory of its own
+// so the same login on different hosts doesn't share an archive.
+func (opts cliOptions) archive() eventArchive {
+	if host := opts.enterpriseHost(); host != "" {
+		return eventArchive{dir: filepath.Join(opts.archiveDir, host)}
+	}
+	return eventArchive{dir: opts.archiveDir}
+}
+
+func (opts cliOptions) renderOptions() render.Options {
+	renderOpts := render.Options{
+		Verbose:  opts.verbose,
+		Output:   opts.output,
+		GroupBy:  opts.groupBy,
+		Host:     opts.enterpriseHost(),
+		Collapse: opts.collapse,
+		Location: opts.location,
+	}
+	if opts.output == render.OUTPUT_TEXT {
+		renderOpts.Highlight = opts.filters.Grep
+	}
+
+	return renderOpts
+}
+
+// splitUsername separates the leading username argument from the options.
+// The username may be omitted when events are read with --input, or from an
+// organization's archive with --org and --offline.
+func splitUsername(args []string) (string, []string, bool) {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		return args[0], args[1:], true
+	}
+	if slices.Contains(args, "--input") || slices.Contains(args, "--org") && slices.Contains(args, "--offline") {
+		return "", args, true
+	}
+	return "", args, false
+}
+
+func argValue(args []string, name string) (string, bool) {
+	if !slices.Contains(args, name) {
+		return "", false
+	}
+
+	idx := slices.Index(args, name)
+	if len(args) > idx+1 {
+		return args[idx+1], true
+	}
+
+	return "", false
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/client"
+	"github.com/dmitriy-zverev/github-activity/events"
+	"github.com/dmitriy-zverev/github-activity/filter"
+	"github.com/dmitriy-zverev/github-activity/render"
+)
+
+func TestParseOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectError bool
+		check       func(t *testing.T, opts cliOptions)
+	}{
+		{
+			name: "Defaults",
+			args: []string{},
+			check: func(t *testing.T, opts cliOptions) {
+				if opts.page != DEFAULT_PAGE_NUM || opts.perPage != DEFAULT_PER_PAGE_EVENTS || opts.filters.Type != DEFAULT_FILTER_TYPE {
+					t.Errorf("Unexpected defaults: %+v", opts)
+				}
+				if !opts.collapse || opts.verbose || opts.output != render.OUTPUT_TEXT || opts.groupBy != render.GROUP_BY_NONE {
+					t.Errorf("Unexpected defaults: %+v", opts)
+				}
+				if opts.timeout != DEFAULT_TIMEOUT {
+					t.Errorf("Expected %s timeout, got %s", DEFAULT_TIMEOUT, opts.timeout)
+				}
+				if opts.client.BaseURL != client.DEFAULT_API_URL {
+					t.Errorf("Expected API URL %s, got %s", client.DEFAULT_API_URL, opts.client.BaseURL)
+				}
+			},
+		},
+		{
+			name: "All flags",
+			args: []string{"-p", "2", "-n", "10", "-f", "PushEvent", "--no-bots", "--grep", "fix", "-o", "json", "--group-by", "repo", "--no-collapse", "--verbose"},
+			check: func(t *testing.T, opts cliOptions) {
+				if opts.page != "2" || opts.perPage != "10" || opts.filters.Type != "PushEvent" {
+					t.Errorf("Unexpected paging or filter: %+v", opts)
+				}
+				if opts.filters.Bots != filter.BOT_FILTER_EXCLUDE || opts.filters.Grep == nil || opts.output != render.OUTPUT_JSON || opts.groupBy != render.GROUP_BY_REPO {
+					t.Errorf("Unexpected options: %+v", opts)
+				}
+				if opts.collapse || !opts.verbose {
+					t.Errorf("Unexpected collapse or verbose: %+v", opts)
+				}
+				if opts.renderOptions().Highlight != nil {
+					t.Error("Expected no highlighting in JSON output")
+				}
+			},
+		},
+		{
+			name: "Interactive",
+			args: []string{"--interactive", "--no-collapse"},
+			check: func(t *testing.T, opts cliOptions) {
+				if !opts.interactive || opts.collapse {
+					t.Errorf("Expected interactive mode without collapsing: %+v", opts)
+				}
+			},
+		},
+		{
+			name: "API URL",
+			args: []string{"--api-url", "http://localhost:8080"},
+			check: func(t *testing.T, opts cliOptions) {
+				if opts.client.BaseURL != "http://localhost:8080" || opts.client.HTTPClient == nil {
+					t.Errorf("Unexpected API client: %+v", opts.client)
+				}
+			},
+		},
+		{
+			name: "Timeout",
+			args: []string{"--timeout", "1m30s"},
+			check: func(t *testing.T, opts cliOptions) {
+				if opts.timeout != 90*time.Second {
+					t.Errorf("Expected 1m30s timeout, got %s", opts.timeout)
+				}
+			},
+		},
+		{
+			name: "No timeout",
+			args: []string{"--timeout", "0"},
+			check: func(t *testing.T, opts cliOptions) {
+				ctx, cancel := opts.fetchContext(context.Background())
+				defer cancel()
+				if _, ok := ctx.Deadline(); ok {
+					t.Error("Expected no deadline with a zero timeout")
+				}
+			},
+		},
+		{
+			name:        "Invalid timeout",
+			args:        []string{"--timeout", "soon"},
+			expectError: true,
+		},
+		{
+			name: "Retry policy",
+			args: []string{"--max-attempts", "5", "--retry-delay", "2s"},
+			check: func(t *testing.T, opts cliOptions) {
+				if opts.client.Retry.MaxAttempts != 5 || opts.client.Retry.BaseDelay != 2*time.Second {
+					t.Errorf("Expected 5 attempts with a 2s delay, got %+v", opts.client.Retry)
+				}
+			},
+		},
+		{
+			name: "Default retry policy",
+			args: []string{},
+			check: func(t *testing.T, opts cliOptions) {
+				if opts.client.Retry != client.DefaultRetryPolicy() {
+					t.Errorf("Expected the default retry policy, got %+v", opts.client.Retry)
+				}
+			},
+		},
+		{
+			name:        "Invalid max attempts",
+			args:        []string{"--max-attempts", "0"},
+			expectError: true,
+		},
+		{
+			name:        "Invalid retry delay",
+			args:        []string{"--retry-delay", "later"},
+			expectError: true,
+		},
+		{
+			name:        "Invalid page number",
+			args:        []string{"-p", "invalid"},
+			expectError: true,
+		},
+		{
+			name:        "Invalid per-page number",
+			args:        []string{"-n", "invalid"},
+			expectError: true,
+		},
+		{
+			name:        "Conflicting bot filters",
+			args:        []string{"--no-bots", "--only-bots"},
+			expectError: true,
+		},
+		{
+			name:        "Invalid grep regex",
+			args:        []string{"--grep", "(", "--regex"},
+			expectError: true,
+		},
+		{
+			name:        "Invalid output format",
+			args:        []string{"-o", "xml"},
+			expectError: true,
+		},
+		{
+			name:        "Invalid group",
+			args:        []string{"--group-by", "week"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := parseOptions(tt.args)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for args %v", tt.args)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			tt.check(t, opts)
+		})
+	}
+}
+
+func TestParseOptionsHost(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	t.Setenv("GH_HOST", "")
+	t.Setenv("GH_TOKEN", "dotcom-token")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "enterprise-token")
+
+	opts, err := parseOptions([]string{"--archive-dir", "archive"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.host != "github.com" || opts.client.BaseURL != client.DEFAULT_API_URL || opts.client.Token != "dotcom-token" {
+		t.Errorf("Unexpected github.com options: host %q, url %q, token %q", opts.host, opts.client.BaseURL, opts.client.Token)
+	}
+	if opts.qualify("octocat") != "octocat" || opts.archive().dir != "archive" || opts.renderOptions().Host != "" {
+		t.Error("Expected github.com output and archive to stay unqualified")
+	}
+
+	t.Setenv("GH_HOST", "ghe.example.com")
+	opts, err = parseOptions([]string{"--archive-dir", "archive"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.client.BaseURL != "https://ghe.example.com/api/v3" || opts.client.Token != "enterprise-token" {
+		t.Errorf("Unexpected GH_HOST options: url %q, token %q", opts.client.BaseURL, opts.client.Token)
+	}
+	if opts.qualify("octocat") != "ghe.example.com/octocat" {
+		t.Errorf("Expected the username qualified with its host, got %q", opts.qualify("octocat"))
+	}
+	if opts.archive().dir != filepath.Join("archive", "ghe.example.com") {
+		t.Errorf("Expected a per-host archive, got %q", opts.archive().dir)
+	}
+	if opts.renderOptions().Host != "ghe.example.com" {
+		t.Errorf("Expected the host in render options, got %q", opts.renderOptions().Host)
+	}
+
+	opts, err = parseOptions([]string{"--host", "https://GHE.other.org/", "--api-url", "http://localhost:8080"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.host != "ghe.other.org" || opts.client.BaseURL != "http://localhost:8080" {
+		t.Errorf("Expected --host over GH_HOST and --api-url over both, got %q, %q", opts.host, opts.client.BaseURL)
+	}
+	if opts.client.Token != "" {
+		t.Errorf("Expected no token sent to --api-url, got %q", opts.client.Token)
+	}
+
+	opts, err = parseOptions([]string{"--host", "ghe.other.org", "--api-url", "http://localhost:8080", "--api-url-token"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.client.Token != "enterprise-token" {
+		t.Errorf("Expected --api-url-token to send the host's token, got %q", opts.client.Token)
+	}
+
+	if _, err := parseOptions([]string{"--ca-bundle", filepath.Join(t.TempDir(), "missing.pem")}); err == nil {
+		t.Error("Expected error for a missing CA bundle")
+	}
+}
+
+func TestParseOptionsGHCredentials(t *testing.T) {
+	hosts := writeTestFile(t, "hosts.yml", "ghe.example.com:\n    oauth_token: ghe_stored\n")
+	t.Setenv("GH_CONFIG_DIR", filepath.Dir(hosts))
+	t.Setenv("GH_HOST", "")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "")
+	t.Setenv("GITHUB_ENTERPRISE_TOKEN", "")
+
+	opts, err := parseOptions([]string{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.host != "ghe.example.com" || opts.client.Token != "ghe_stored" {
+		t.Errorf("Expected the gh CLI host and token, got %q, %q", opts.host, opts.client.Token)
+	}
+	if !strings.Contains(opts.credentials.String(), hosts) {
+		t.Errorf("Expected the credential message to name %s, got %q", hosts, opts.credentials)
+	}
+
+	t.Setenv("GH_ENTERPRISE_TOKEN", "from-env")
+	opts, err = parseOptions([]string{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.client.Token != "from-env" {
+		t.Errorf("Expected an explicit token to win over gh's, got %q", opts.client.Token)
+	}
+}
+
+func TestApplyFilters(t *testing.T) {
+	activities := []events.Event{
+		eventFromJSON(t, `{"type":"PushEvent","actor":{"login":"octocat"},"payload":{"commits":[{"message":"fix bug"}]}}`),
+		eventFromJSON(t, `{"type":"PullRequestEvent","actor":{"login":"dependabot[bot]"},"payload":{"pull_request":{"title":"Bump deps"}}}`),
+	}
+
+	opts, _ := parseOptions([]string{"--no-bots", "--grep", "fix"})
+	result, err := filter.Apply(activities, opts.filters)
+	if err != nil || len(result) != 1 {
+		t.Errorf("Expected 1 event and no error, got %d and %v", len(result), err)
+	}
+
+	opts, _ = parseOptions([]string{"-f", "Release"})
+	_, err = filter.Apply(activities, opts.filters)
+	var noResult filter.NoResultError
+	if !errors.As(err, &noResult) || noResult.Filter != "'Release' filter" {
+		t.Errorf("Expected no result error for filter, got %v", err)
+	}
+
+	opts, _ = parseOptions([]string{"--grep", "missing"})
+	_, err = filter.Apply(activities, opts.filters)
+	if !errors.As(err, &noResult) || noResult.Filter != "'missing' search" {
+		t.Errorf("Expected no result error for search, got %v", err)
+	}
+}
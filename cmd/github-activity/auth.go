@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/dmitriy-zverev/github-activity/client"
+)
+
+// credentials is the API token for a host together with where it was found,
+// so the user can tell which account requests are made with.
+type credentials struct {
+	host   string
+	token  string
+	source string
+	// keyring is set when gh is logged in to host but keeps its token in
+	// the system keyring, out of reach of hosts.yml
+	keyring bool
+}
+
+// resolveCredentials picks the token for host. The variable named by the
+// config file's token_env comes first, then the usual token variables, then
+// the token the gh CLI stored in hosts.yml.
+func resolveCredentials(host, tokenEnv string, getenv func(string) string, gh ghHosts) credentials {
+	if token := getenv(tokenEnv); tokenEnv != "" && token != "" {
+		return credentials{host: host, token: token, source: "$" + tokenEnv}
+	}
+
+	if name, token := hostToken(host, getenv); token != "" {
+		return credentials{host: host, token: token, source: "$" + name}
+	}
+
+	if token := gh.tokens[host]; token != "" {
+		return credentials{host: host, token: token, source: "gh CLI config " + gh.path}
+	}
+
+	return credentials{host: host, keyring: slices.Contains(gh.hosts, host)}
+}
+
+// hostToken looks up the token for host in the environment. The variable
+// named after the host, such as GITHUB_ACTIVITY_TOKEN_GHE_EXAMPLE_COM, comes
+// first. Then it goes the way the gh CLI does: GH_TOKEN or GITHUB_TOKEN for
+// github.com, GH_ENTERPRISE_TOKEN or GITHUB_ENTERPRISE_TOKEN for any other
+// host, which makes those shared by every enterprise host. It returns the
+// variable the token came from along with it.
+func hostToken(host string, getenv func(string) string) (string, string) {
+	names := []string{hostTokenVar(host)}
+	if client.IsEnterprise(host) {
+		names = append(names, ENTERPRISE_TOKEN_VARS...)
+	} else {
+		names = append(names, GITHUB_TOKEN_VARS...)
+	}
+
+	for _, name := range names {
+		if token := getenv(name); token != "" {
+			return name, token
+		}
+	}
+	return "", ""
+}
+
+// hostTokenVar is the variable holding the token for host alone, named after
+// the host with everything but letters and digits turned into underscores.
+func hostTokenVar(host string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, host)
+	return HOST_TOKEN_ENV_PREFIX + name
+}
+
+func (creds credentials) String() string {
+	switch {
+	case creds.token != "":
+		return fmt.Sprintf("Authenticating to %s with the token from %s", creds.host, creds.source)
+	case creds.keyring:
+		return fmt.Sprintf(
+			"No token for %s (gh keeps it in the system keyring, set GH_TOKEN=$(gh auth token) to use it), sending unauthenticated requests",
+			creds.host,
+		)
+	default:
+		return fmt.Sprintf("No token for %s, sending unauthenticated requests", creds.host)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHostToken(t *testing.T) {
+	env := map[string]string{
+		"GITHUB_TOKEN":        "dotcom",
+		"GH_ENTERPRISE_TOKEN": "enterprise",
+	}
+	getenv := func(name string) string { return env[name] }
+
+	if name, token := hostToken("github.com", getenv); token != "dotcom" || name != "GITHUB_TOKEN" {
+		t.Errorf("Expected the github.com token from GITHUB_TOKEN, got %q from %q", token, name)
+	}
+	if _, token := hostToken("ghe.example.com", getenv); token != "enterprise" {
+		t.Errorf("Expected the enterprise token, got %q", token)
+	}
+
+	env["GH_TOKEN"] = "preferred"
+	if _, token := hostToken("github.com", getenv); token != "preferred" {
+		t.Errorf("Expected GH_TOKEN to win over GITHUB_TOKEN, got %q", token)
+	}
+
+	env["GITHUB_ACTIVITY_TOKEN_GHE_EXAMPLE_COM"] = "example"
+	if name, token := hostToken("ghe.example.com", getenv); token != "example" || name != "GITHUB_ACTIVITY_TOKEN_GHE_EXAMPLE_COM" {
+		t.Errorf("Expected the host's own token to win, got %q from %q", token, name)
+	}
+	if _, token := hostToken("ghe.other.org", getenv); token != "enterprise" {
+		t.Errorf("Expected other enterprise hosts to fall back to the shared token, got %q", token)
+	}
+	if name := hostTokenVar("ghe-1.example.com:8443"); name != "GITHUB_ACTIVITY_TOKEN_GHE_1_EXAMPLE_COM_8443" {
+		t.Errorf("Unexpected variable name %q", name)
+	}
+
+	delete(env, "GITHUB_ACTIVITY_TOKEN_GHE_EXAMPLE_COM")
+	delete(env, "GH_ENTERPRISE_TOKEN")
+	if _, token := hostToken("ghe.example.com", getenv); token != "" {
+		t.Errorf("Expected github.com tokens not to be sent to other hosts, got %q", token)
+	}
+}
+
+func TestResolveCredentials(t *testing.T) {
+	gh := ghHosts{
+		path:   "/home/octocat/.config/gh/hosts.yml",
+		hosts:  []string{"github.com", "ghe.example.com"},
+		tokens: map[string]string{"github.com": "gho_stored"},
+	}
+
+	tests := []struct {
+		name           string
+		host           string
+		tokenEnv       string
+		env            map[string]string
+		expectedToken  string
+		expectedSource string
+	}{
+		{
+			name:           "Environment wins",
+			host:           "github.com",
+			env:            map[string]string{"GH_TOKEN": "from-env"},
+			expectedToken:  "from-env",
+			expectedSource: "$GH_TOKEN",
+		},
+		{
+			name:           "Config token_env wins",
+			host:           "github.com",
+			tokenEnv:       "WORK_TOKEN",
+			env:            map[string]string{"GH_TOKEN": "from-env", "WORK_TOKEN": "work"},
+			expectedToken:  "work",
+			expectedSource: "$WORK_TOKEN",
+		},
+		{
+			name:           "Unset token_env falls through",
+			host:           "github.com",
+			tokenEnv:       "WORK_TOKEN",
+			env:            map[string]string{"GH_TOKEN": "from-env"},
+			expectedToken:  "from-env",
+			expectedSource: "$GH_TOKEN",
+		},
+		{
+			name:           "gh CLI token",
+			host:           "github.com",
+			expectedToken:  "gho_stored",
+			expectedSource: "gh CLI config /home/octocat/.config/gh/hosts.yml",
+		},
+		{
+			name:           "Token in the keyring",
+			host:           "ghe.example.com",
+			expectedSource: "keyring",
+		},
+		{
+			name:           "Unknown host",
+			host:           "ghe.other.org",
+			expectedSource: "unauthenticated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			creds := resolveCredentials(tt.host, tt.tokenEnv, func(name string) string { return tt.env[name] }, gh)
+			if creds.token != tt.expectedToken {
+				t.Errorf("Expected token %q, got %q", tt.expectedToken, creds.token)
+			}
+			if !strings.Contains(creds.String(), tt.expectedSource) || !strings.Contains(creds.String(), tt.host) {
+				t.Errorf("Expected a message naming %s and %s, got %q", tt.host, tt.expectedSource, creds)
+			}
+		})
+	}
+}
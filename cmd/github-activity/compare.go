@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/client"
+	"github.com/dmitriy-zverev/github-activity/events"
+	"github.com/dmitriy-zverev/github-activity/filter"
+	"github.com/dmitriy-zverev/github-activity/render"
+)
+
+type userFetchResult struct {
+	username   string
+	activities []events.Event
+	err        error
+}
+
+type userComparison struct {
+	Username     string         `json:"username"`
+	TotalEvents  int            `json:"total_events"`
+	ByType       map[string]int `json:"by_type"`
+	ReposTouched int            `json:"repos_touched"`
+	LastActive   *time.Time     `json:"last_active,omitempty"`
+}
+
+func runCompare(ctx context.Context, args []string) error {
+	var usernames []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		usernames = append(usernames, arg)
+	}
+
+	if len(usernames) < 2 {
+		return errUsage
+	}
+
+	opts, err := parseOptions(args[len(usernames):])
+	if err != nil {
+		return usageError("parsing %v", err)
+	}
+
+	ctx, cancel := opts.fetchContext(ctx)
+	defer cancel()
+
+	if opts.input != "" {
+		return usageError("parsing input: --input can't be used with compare")
+	}
+
+	if opts.output == render.OUTPUT_TEXT && opts.online() {
+		var qualified []string
+		for _, username := range usernames {
+			qualified = append(qualified, opts.qualify(username))
+		}
+		fmt.Fprintf(os.Stderr, "Fetching up to %d events for %s...\n", client.MAX_EVENTS, strings.Join(qualified, ", "))
+		fmt.Fprintln(os.Stderr, opts.credentials)
+	}
+
+	var comparisons []userComparison
+	fetch := func(username string) ([]events.Event, error) {
+		return allUserActivities(ctx, username, opts)
+	}
+
+	for _, result := range fetchUsers(usernames, fetch) {
+		if result.err != nil {
+			return fmt.Errorf("fetching activity for '%s': %w", opts.qualify(result.username), result.err)
+		}
+
+		// An empty result after filtering is still a valid row in the comparison
+		activities, _ := filter.Apply(result.activities, opts.filters)
+		comparisons = append(comparisons, compareUser(result.username, activities))
+	}
+
+	if opts.output == render.OUTPUT_JSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(comparisons); err != nil {
+			return fmt.Errorf("printing user comparison: %w", err)
+		}
+		return nil
+	}
+
+	if err := printComparison(os.Stdout, comparisons, opts.location); err != nil {
+		return fmt.Errorf("printing user comparison: %w", err)
+	}
+	return nil
+}
+
+// fetchUsers fetches every user concurrently and returns the results in the
+// same order as usernames.
+func fetchUsers(usernames []string, fetch func(string) ([]events.Event, error)) []userFetchResult {
+	results := make([]userFetchResult, len(usernames))
+
+	var wg sync.WaitGroup
+	for i, username := range usernames {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			activities, err := fetch(username)
+			results[i] = userFetchResult{
+				username:   username,
+				activities: activities,
+				err:        err,
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func compareUser(username string, activities []events.Event) userComparison {
+	comparison := userComparison{
+		Username:    username,
+		TotalEvents: len(activities),
+		ByType:      map[string]int{},
+	}
+
+	repos := map[string]bool{}
+	for _, activity := range activities {
+		comparison.ByType[activity.Type]++
+		repos[activity.Repo.Name] = true
+
+		if activity.CreatedAt.IsZero() {
+			continue
+		}
+		if comparison.LastActive == nil || activity.CreatedAt.After(*comparison.LastActive) {
+			createdAt := activity.CreatedAt
+			comparison.LastActive = &createdAt
+		}
+	}
+	comparison.ReposTouched = len(repos)
+
+	return comparison
+}
+
+func printComparison(w io.Writer, comparisons []userComparison, location *time.Location) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	var types []string
+	for _, comparison := range comparisons {
+		for eventType := range comparison.ByType {
+			if !slices.Contains(types, eventType) {
+				types = append(types, eventType)
+			}
+		}
+	}
+	slices.Sort(types)
+
+	row := func(label string, value func(userComparison) string) {
+		fmt.Fprintf(tw, "%s\t", label)
+		for _, comparison := range comparisons {
+			fmt.Fprintf(tw, "%s\t", value(comparison))
+		}
+		fmt.Fprintln(tw)
+	}
+
+	row("", func(c userComparison) string { return c.Username })
+	row("Total events", func(c userComparison) string { return fmt.Sprint(c.TotalEvents) })
+	for _, eventType := range types {
+		row(eventType, func(c userComparison) string { return fmt.Sprint(c.ByType[eventType]) })
+	}
+	row("Repos touched", func(c userComparison) string { return fmt.Sprint(c.ReposTouched) })
+	row("Last active", func(c userComparison) string {
+		if c.LastActive == nil {
+			return "-"
+		}
+		return c.LastActive.In(location).Format(DATE_TIME_FORMAT)
+	})
+
+	return tw.Flush()
+}
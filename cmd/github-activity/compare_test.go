@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+func TestFetchUsers(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	fetch := func(username string) ([]events.Event, error) {
+		// Every fetch blocks until all of them have started, which only
+		// completes if they run concurrently.
+		if calls.Add(1) == 3 {
+			close(release)
+		}
+		<-release
+
+		if username == "missing" {
+			return nil, errors.New("not found")
+		}
+		return []events.Event{{Type: events.PUSH_EVENT}}, nil
+	}
+
+	done := make(chan []userFetchResult)
+	go func() {
+		done <- fetchUsers([]string{"alice", "missing", "bob"}, fetch)
+	}()
+
+	var results []userFetchResult
+	select {
+	case results = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Users were not fetched concurrently")
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	for i, username := range []string{"alice", "missing", "bob"} {
+		if results[i].username != username {
+			t.Errorf("Expected result %d for %s, got %s", i, username, results[i].username)
+		}
+	}
+	if results[1].err == nil || results[0].err != nil || len(results[2].activities) != 1 {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+}
+
+func TestCompareUser(t *testing.T) {
+	activities := []events.Event{
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"a/one"},"created_at":"2025-03-01T12:00:00Z"}`),
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"a/two"},"created_at":"2025-03-03T08:00:00Z"}`),
+		eventFromJSON(t, `{"type":"WatchEvent","repo":{"name":"a/one"}}`),
+	}
+
+	comparison := compareUser("alice", activities)
+
+	if comparison.TotalEvents != 3 || comparison.ByType[events.PUSH_EVENT] != 2 || comparison.ReposTouched != 2 {
+		t.Errorf("Unexpected comparison: %+v", comparison)
+	}
+	if comparison.LastActive == nil || !comparison.LastActive.Equal(time.Date(2025, 3, 3, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected last active time: %v", comparison.LastActive)
+	}
+
+	if empty := compareUser("bob", nil); empty.LastActive != nil || empty.TotalEvents != 0 {
+		t.Errorf("Expected empty comparison, got %+v", empty)
+	}
+}
+
+func TestPrintComparison(t *testing.T) {
+	lastActive := time.Date(2025, 3, 3, 8, 0, 0, 0, time.UTC)
+	comparisons := []userComparison{
+		{Username: "alice", TotalEvents: 3, ByType: map[string]int{events.PUSH_EVENT: 2, events.WATCH_EVENT: 1}, ReposTouched: 2, LastActive: &lastActive},
+		{Username: "bob", TotalEvents: 1, ByType: map[string]int{events.ISSUES_EVENT: 1}, ReposTouched: 1},
+	}
+
+	var buf bytes.Buffer
+	if err := printComparison(&buf, comparisons, time.UTC); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"               alice             bob",
+		"Total events   3                 1",
+		"IssuesEvent    0                 1",
+		"PushEvent      2                 0",
+		"WatchEvent     1                 0",
+		"Repos touched  2                 1",
+		"Last active    2025-03-03 08:00  -",
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, got %d:\n%s", len(expected), len(lines), buf.String())
+	}
+	for i := range lines {
+		if strings.TrimRight(lines[i], " ") != expected[i] {
+			t.Errorf("Expected line %d to be %q, got %q", i, expected[i], lines[i])
+		}
+	}
+}
@@ -0,0 +1,462 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+	"github.com/dmitriy-zverev/github-activity/render"
+)
+
+// completionCommand is a subcommand offered in place of a username.
+type completionCommand struct {
+	name        string
+	description string
+	// arg is what the positional arguments after the command complete to
+	arg int
+}
+
+// completionFlag is an option the completion scripts offer. Flags without
+// commands apply to every command that fetches events.
+type completionFlag struct {
+	name        string
+	description string
+	arg         int
+	words       []string
+	commands    []string
+}
+
+var completionCommands = []completionCommand{
+	{name: STATS_COMMAND, description: "Summarize activity by type, repository and weekday", arg: COMPLETE_USERS},
+	{name: HEATMAP_COMMAND, description: "Draw a contribution heatmap", arg: COMPLETE_USERS},
+	{name: HISTOGRAM_COMMAND, description: "Show activity by hour and weekday", arg: COMPLETE_USERS},
+	{name: STREAKS_COMMAND, description: "Find streaks and gaps in activity", arg: COMPLETE_USERS},
+	{name: COMPARE_COMMAND, description: "Compare the activity of several users", arg: COMPLETE_USERS},
+	{name: TEAM_COMMAND, description: "Digest the activity of a team roster", arg: COMPLETE_TEAMS},
+	{name: SYNC_COMMAND, description: "Save activity to the local archive", arg: COMPLETE_USERS},
+	{name: FAKE_SERVER_COMMAND, description: "Serve fixtures as a fake GitHub API", arg: COMPLETE_NONE},
+	{name: CONFIG_COMMAND, description: "Print the effective configuration", arg: COMPLETE_NONE},
+	{name: COMPLETION_COMMAND, description: "Print a shell completion script", arg: COMPLETE_SHELLS},
+	{name: HELP_COMMANDS[0], description: "Show usage", arg: COMPLETE_NONE},
+}
+
+var completionFlags = []completionFlag{
+	{name: "-p", description: "Page number", arg: COMPLETE_VALUE},
+	{name: "-n", description: "Events per page", arg: COMPLETE_VALUE},
+	{name: "-f", description: "Event type", arg: COMPLETE_WORDS, words: events.EVENT_TYPES},
+	{name: "-o", description: "Output format", arg: COMPLETE_WORDS, words: render.OUTPUT_FORMATS},
+	{name: "--output", description: "Output format", arg: COMPLETE_WORDS, words: render.OUTPUT_FORMATS},
+	{name: "--group-by", description: "Group events", arg: COMPLETE_WORDS, words: render.GROUP_BY_OPTIONS},
+	{name: "-v", description: "List commits under pushes"},
+	{name: "--verbose", description: "List commits under pushes"},
+	{name: "--no-collapse", description: "List similar consecutive events separately"},
+	{name: "--interactive", description: "Browse events in a full-screen list"},
+	{name: "--grep", description: "Search commit messages, titles and comments", arg: COMPLETE_VALUE},
+	{name: "--regex", description: "Treat the --grep pattern as a regular expression"},
+	{name: "--ignore-case", description: "Case-insensitive --grep"},
+	{name: "--no-bots", description: "Hide events from bots"},
+	{name: "--only-bots", description: "Show only events from bots"},
+	{name: "--all-bots", description: "Show events from bots and people alike"},
+	{name: "--bot-denylist", description: "Extra logins treated as bots", arg: COMPLETE_VALUE},
+	{name: "--tz", description: "Time zone", arg: COMPLETE_VALUE},
+	{name: "--offline", description: "Read events from the local archive"},
+	{name: "--archive-dir", description: "Location of the local archive", arg: COMPLETE_DIR},
+	{name: "--org", description: "Organizations to archive, or the archived one to show", arg: COMPLETE_VALUE},
+	{name: "--input", description: "Read saved events from a file", arg: COMPLETE_FILE},
+	{name: "--record", description: "Save HTTP exchanges as fixtures", arg: COMPLETE_DIR},
+	{name: "--replay", description: "Answer HTTP requests from fixtures", arg: COMPLETE_DIR},
+	{name: "--host", description: "GitHub Enterprise Server host", arg: COMPLETE_VALUE},
+	{name: "--ca-bundle", description: "Extra PEM certificates to trust", arg: COMPLETE_FILE},
+	{name: "--api-url", description: "GitHub API base URL", arg: COMPLETE_VALUE},
+	{name: "--api-url-token", description: "Send the host's token to --api-url"},
+	{name: "--timeout", description: "Give up on the API after a duration", arg: COMPLETE_VALUE},
+	{name: "--max-attempts", description: "Tries per API request", arg: COMPLETE_VALUE},
+	{name: "--retry-delay", description: "Wait before the first retry", arg: COMPLETE_VALUE},
+	{name: "--config", description: "Configuration file", arg: COMPLETE_FILE},
+	{name: "--profile", description: "Configuration profile", arg: COMPLETE_PROFILES},
+	{name: "--weeks", description: "Weeks to draw", arg: COMPLETE_VALUE, commands: []string{HEATMAP_COMMAND}},
+	{name: "--until", description: "Last day to draw", arg: COMPLETE_VALUE, commands: []string{HEATMAP_COMMAND}},
+	{name: "--gap-days", description: "Days without activity that make a gap", arg: COMPLETE_VALUE, commands: []string{STREAKS_COMMAND}},
+	{name: "--team", description: "Team roster or team name", arg: COMPLETE_TEAMS, commands: []string{SYNC_COMMAND}},
+	{name: "--fixtures", description: "Fixture directory", arg: COMPLETE_DIR, commands: []string{FAKE_SERVER_COMMAND}},
+	{name: "--addr", description: "Address to listen on", arg: COMPLETE_VALUE, commands: []string{FAKE_SERVER_COMMAND}},
+	{name: "--rate-limit", description: "Requests allowed before a 403", arg: COMPLETE_VALUE, commands: []string{FAKE_SERVER_COMMAND}},
+	{name: "--fail-every", description: "Fail every nth request", arg: COMPLETE_VALUE, commands: []string{FAKE_SERVER_COMMAND}},
+	{name: "--fail-status", description: "Status of the failed requests", arg: COMPLETE_VALUE, commands: []string{FAKE_SERVER_COMMAND}},
+}
+
+// runCompletion prints the completion script for a shell. The scripts call
+// back into the binary with --list for names that live in the archive or the
+// config file, so those stay current without reinstalling the script.
+func runCompletion(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return errUsage
+	}
+
+	if args[0] == COMPLETION_LIST_FLAG {
+		if len(args) < 2 {
+			return errUsage
+		}
+		return listCompletions(args[1], args[2:])
+	}
+
+	switch args[0] {
+	case SHELL_BASH:
+		bashCompletion(os.Stdout)
+	case SHELL_ZSH:
+		zshCompletion(os.Stdout)
+	case SHELL_FISH:
+		fishCompletion(os.Stdout)
+	default:
+		return usageError("parsing shell: unknown shell '%s', expected one of: %s", args[0], strings.Join(COMPLETION_SHELLS, ", "))
+	}
+	return nil
+}
+
+// listCompletions prints one name of the given kind per line.
+func listCompletions(kind string, args []string) error {
+	opts, err := parseOptions(args)
+	if err != nil {
+		return usageError("parsing %v", err)
+	}
+
+	var names []string
+	switch kind {
+	case COMPLETION_LIST_USERS:
+		names, err = opts.archive().users().usernames()
+	case COMPLETION_LIST_TEAMS:
+		names = slices.Sorted(maps.Keys(opts.config.Teams))
+	case COMPLETION_LIST_PROFILES:
+		var config fileConfig
+		config, err = loadConfig(opts.configPath)
+		names = slices.Sorted(maps.Keys(config.Profiles))
+	default:
+		return usageError("parsing list: unknown list '%s', expected one of: %s, %s, %s", kind, COMPLETION_LIST_USERS, COMPLETION_LIST_TEAMS, COMPLETION_LIST_PROFILES)
+	}
+	if err != nil {
+		return fmt.Errorf("listing %s: %w", kind, err)
+	}
+
+	for _, name := range names {
+		fmt.Println(name)
+	}
+	return nil
+}
+
+// usernames returns the users with events in the archive.
+func (archive eventArchive) usernames() ([]string, error) {
+	entries, err := os.ReadDir(archive.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var usernames []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		segments, _ := filepath.Glob(filepath.Join(archive.dir, entry.Name(), "*"+ARCHIVE_SEGMENT_EXT))
+		if len(segments) > 0 {
+			usernames = append(usernames, entry.Name())
+		}
+	}
+
+	return usernames, nil
+}
+
+// completionFlagNames returns the flags available to command, where an empty
+// command is the default activity listing.
+func completionFlagNames(command string) []string {
+	var names []string
+	for _, flag := range completionFlags {
+		if flag.appliesTo(command) {
+			names = append(names, flag.name)
+		}
+	}
+	return names
+}
+
+func (flag completionFlag) appliesTo(command string) bool {
+	if flag.commands != nil {
+		return slices.Contains(flag.commands, command)
+	}
+	return command != FAKE_SERVER_COMMAND && command != COMPLETION_COMMAND
+}
+
+// flagsWithArg returns the names of the flags that take an argument of the
+// given kind.
+func flagsWithArg(arg int) []string {
+	var names []string
+	for _, flag := range completionFlags {
+		if flag.arg == arg {
+			names = append(names, flag.name)
+		}
+	}
+	return names
+}
+
+// commandsWithArg returns the commands whose positional arguments are of the
+// given kind.
+func commandsWithArg(arg int) []string {
+	var names []string
+	for _, command := range completionCommands {
+		if command.arg == arg {
+			names = append(names, command.name)
+		}
+	}
+	return names
+}
+
+// scopedCommands returns the commands with flags of their own, which need a
+// flag list separate from the shared one.
+func scopedCommands() []string {
+	var commands []string
+	for _, flag := range completionFlags {
+		for _, command := range flag.commands {
+			if !slices.Contains(commands, command) {
+				commands = append(commands, command)
+			}
+		}
+	}
+	return commands
+}
+
+func commandNames() []string {
+	names := make([]string, 0, len(completionCommands))
+	for _, command := range completionCommands {
+		names = append(names, command.name)
+	}
+	return names
+}
+
+func bashCompletion(w io.Writer) {
+	fmt.Fprintf(w, "# bash completion for %s\n", APP_NAME)
+	fmt.Fprintf(w, "# Install with: source <(%s completion bash)\n\n", APP_NAME)
+	fmt.Fprintln(w, "_github_activity_list() {")
+	fmt.Fprintf(w, "    \"${COMP_WORDS[0]}\" completion %s \"$1\" 2>/dev/null\n", COMPLETION_LIST_FLAG)
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "_github_activity() {")
+	fmt.Fprintln(w, "    local cur=\"${COMP_WORDS[COMP_CWORD]}\"")
+	fmt.Fprintln(w, "    local prev=\"${COMP_WORDS[COMP_CWORD-1]}\"")
+	fmt.Fprintln(w, "    local command=\"\"")
+	fmt.Fprintln(w, "    if [ \"$COMP_CWORD\" -gt 1 ]; then")
+	fmt.Fprintln(w, "        command=\"${COMP_WORDS[1]}\"")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "    case \"$prev\" in")
+	for _, flag := range completionFlags {
+		if flag.arg == COMPLETE_WORDS {
+			fmt.Fprintf(w, "    %s)\n", flag.name)
+			fmt.Fprintf(w, "        COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(flag.words, " "))
+			fmt.Fprintln(w, "        return ;;")
+		}
+	}
+	fmt.Fprintf(w, "    %s)\n", strings.Join(flagsWithArg(COMPLETE_FILE), "|"))
+	fmt.Fprintln(w, "        COMPREPLY=($(compgen -f -- \"$cur\"))")
+	fmt.Fprintln(w, "        return ;;")
+	fmt.Fprintf(w, "    %s)\n", strings.Join(flagsWithArg(COMPLETE_DIR), "|"))
+	fmt.Fprintln(w, "        COMPREPLY=($(compgen -d -- \"$cur\"))")
+	fmt.Fprintln(w, "        return ;;")
+	fmt.Fprintf(w, "    %s)\n", strings.Join(flagsWithArg(COMPLETE_PROFILES), "|"))
+	fmt.Fprintf(w, "        COMPREPLY=($(compgen -W \"$(_github_activity_list %s)\" -- \"$cur\"))\n", COMPLETION_LIST_PROFILES)
+	fmt.Fprintln(w, "        return ;;")
+	fmt.Fprintf(w, "    %s)\n", strings.Join(flagsWithArg(COMPLETE_TEAMS), "|"))
+	fmt.Fprintf(w, "        COMPREPLY=($(compgen -W \"$(_github_activity_list %s)\" -- \"$cur\") $(compgen -f -- \"$cur\"))\n", COMPLETION_LIST_TEAMS)
+	fmt.Fprintln(w, "        return ;;")
+	fmt.Fprintf(w, "    %s)\n", strings.Join(flagsWithArg(COMPLETE_VALUE), "|"))
+	fmt.Fprintln(w, "        return ;;")
+	fmt.Fprintln(w, "    esac")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "    if [[ \"$cur\" == -* ]]; then")
+	fmt.Fprintln(w, "        local flags")
+	fmt.Fprintln(w, "        case \"$command\" in")
+	for _, command := range append(scopedCommands(), COMPLETION_COMMAND) {
+		fmt.Fprintf(w, "        %s) flags=\"%s\" ;;\n", command, strings.Join(completionFlagNames(command), " "))
+	}
+	fmt.Fprintf(w, "        *) flags=\"%s\" ;;\n", strings.Join(completionFlagNames(""), " "))
+	fmt.Fprintln(w, "        esac")
+	fmt.Fprintln(w, "        COMPREPLY=($(compgen -W \"$flags\" -- \"$cur\"))")
+	fmt.Fprintln(w, "        return")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "    if [ \"$COMP_CWORD\" -eq 1 ]; then")
+	fmt.Fprintf(w, "        COMPREPLY=($(compgen -W \"%s $(_github_activity_list %s)\" -- \"$cur\"))\n", strings.Join(commandNames(), " "), COMPLETION_LIST_USERS)
+	fmt.Fprintln(w, "        return")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "    case \"$command\" in")
+	fmt.Fprintf(w, "    %s)\n", strings.Join(commandsWithArg(COMPLETE_USERS), "|"))
+	fmt.Fprintf(w, "        COMPREPLY=($(compgen -W \"$(_github_activity_list %s)\" -- \"$cur\")) ;;\n", COMPLETION_LIST_USERS)
+	fmt.Fprintf(w, "    %s)\n", strings.Join(commandsWithArg(COMPLETE_TEAMS), "|"))
+	fmt.Fprintln(w, "        if [ \"$COMP_CWORD\" -eq 2 ]; then")
+	fmt.Fprintf(w, "            COMPREPLY=($(compgen -W \"$(_github_activity_list %s)\" -- \"$cur\") $(compgen -f -- \"$cur\"))\n", COMPLETION_LIST_TEAMS)
+	fmt.Fprintln(w, "        fi ;;")
+	fmt.Fprintf(w, "    %s)\n", strings.Join(commandsWithArg(COMPLETE_SHELLS), "|"))
+	fmt.Fprintln(w, "        if [ \"$COMP_CWORD\" -eq 2 ]; then")
+	fmt.Fprintf(w, "            COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(COMPLETION_SHELLS, " "))
+	fmt.Fprintln(w, "        fi ;;")
+	fmt.Fprintln(w, "    esac")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "complete -F _github_activity %s\n", APP_NAME)
+}
+
+func zshCompletion(w io.Writer) {
+	fmt.Fprintf(w, "#compdef %s\n", APP_NAME)
+	fmt.Fprintf(w, "# Install with: %s completion zsh > \"${fpath[1]}/_%s\"\n\n", APP_NAME, APP_NAME)
+	fmt.Fprintln(w, "_github_activity_list() {")
+	fmt.Fprintf(w, "    \"${words[1]}\" completion %s \"$1\" 2>/dev/null\n", COMPLETION_LIST_FLAG)
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "_github_activity() {")
+	fmt.Fprintln(w, "    local cur=\"${words[CURRENT]}\" prev=\"${words[CURRENT-1]}\" command=\"\"")
+	fmt.Fprintln(w, "    local -a flags commands")
+	fmt.Fprintln(w, "    if (( CURRENT > 2 )); then")
+	fmt.Fprintln(w, "        command=\"${words[2]}\"")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "    case \"$prev\" in")
+	for _, flag := range completionFlags {
+		if flag.arg == COMPLETE_WORDS {
+			fmt.Fprintf(w, "    %s)\n", flag.name)
+			fmt.Fprintf(w, "        compadd -- %s\n", strings.Join(flag.words, " "))
+			fmt.Fprintln(w, "        return ;;")
+		}
+	}
+	fmt.Fprintf(w, "    %s)\n", strings.Join(flagsWithArg(COMPLETE_FILE), "|"))
+	fmt.Fprintln(w, "        _files")
+	fmt.Fprintln(w, "        return ;;")
+	fmt.Fprintf(w, "    %s)\n", strings.Join(flagsWithArg(COMPLETE_DIR), "|"))
+	fmt.Fprintln(w, "        _files -/")
+	fmt.Fprintln(w, "        return ;;")
+	fmt.Fprintf(w, "    %s)\n", strings.Join(flagsWithArg(COMPLETE_PROFILES), "|"))
+	fmt.Fprintf(w, "        compadd -- ${(f)\"$(_github_activity_list %s)\"}\n", COMPLETION_LIST_PROFILES)
+	fmt.Fprintln(w, "        return ;;")
+	fmt.Fprintf(w, "    %s)\n", strings.Join(flagsWithArg(COMPLETE_TEAMS), "|"))
+	fmt.Fprintf(w, "        compadd -- ${(f)\"$(_github_activity_list %s)\"}\n", COMPLETION_LIST_TEAMS)
+	fmt.Fprintln(w, "        _files")
+	fmt.Fprintln(w, "        return ;;")
+	fmt.Fprintf(w, "    %s)\n", strings.Join(flagsWithArg(COMPLETE_VALUE), "|"))
+	fmt.Fprintln(w, "        return ;;")
+	fmt.Fprintln(w, "    esac")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "    if [[ \"$cur\" == -* ]]; then")
+	fmt.Fprintln(w, "        case \"$command\" in")
+	for _, command := range append(scopedCommands(), COMPLETION_COMMAND) {
+		fmt.Fprintf(w, "        %s) flags=(%s) ;;\n", command, zshDescriptions(command))
+	}
+	fmt.Fprintf(w, "        *) flags=(%s) ;;\n", zshDescriptions(""))
+	fmt.Fprintln(w, "        esac")
+	fmt.Fprintln(w, "        _describe 'option' flags")
+	fmt.Fprintln(w, "        return")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "    if (( CURRENT == 2 )); then")
+	var commands []string
+	for _, command := range completionCommands {
+		commands = append(commands, zshQuote(command.name+":"+command.description))
+	}
+	fmt.Fprintf(w, "        commands=(%s)\n", strings.Join(commands, " "))
+	fmt.Fprintln(w, "        _describe 'command' commands")
+	fmt.Fprintf(w, "        compadd -- ${(f)\"$(_github_activity_list %s)\"}\n", COMPLETION_LIST_USERS)
+	fmt.Fprintln(w, "        return")
+	fmt.Fprintln(w, "    fi")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "    case \"$command\" in")
+	fmt.Fprintf(w, "    %s)\n", strings.Join(commandsWithArg(COMPLETE_USERS), "|"))
+	fmt.Fprintf(w, "        compadd -- ${(f)\"$(_github_activity_list %s)\"} ;;\n", COMPLETION_LIST_USERS)
+	fmt.Fprintf(w, "    %s)\n", strings.Join(commandsWithArg(COMPLETE_TEAMS), "|"))
+	fmt.Fprintln(w, "        if (( CURRENT == 3 )); then")
+	fmt.Fprintf(w, "            compadd -- ${(f)\"$(_github_activity_list %s)\"}\n", COMPLETION_LIST_TEAMS)
+	fmt.Fprintln(w, "            _files")
+	fmt.Fprintln(w, "        fi ;;")
+	fmt.Fprintf(w, "    %s)\n", strings.Join(commandsWithArg(COMPLETE_SHELLS), "|"))
+	fmt.Fprintln(w, "        if (( CURRENT == 3 )); then")
+	fmt.Fprintf(w, "            compadd -- %s\n", strings.Join(COMPLETION_SHELLS, " "))
+	fmt.Fprintln(w, "        fi ;;")
+	fmt.Fprintln(w, "    esac")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "_github_activity \"$@\"")
+}
+
+// zshDescriptions returns the flags of command as name:description pairs for
+// _describe.
+func zshDescriptions(command string) string {
+	var pairs []string
+	for _, flag := range completionFlags {
+		if flag.appliesTo(command) {
+			pairs = append(pairs, zshQuote(flag.name+":"+flag.description))
+		}
+	}
+	return strings.Join(pairs, " ")
+}
+
+func zshQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+func fishCompletion(w io.Writer) {
+	fmt.Fprintf(w, "# fish completion for %s\n", APP_NAME)
+	fmt.Fprintf(w, "# Install with: %s completion fish > ~/.config/fish/completions/%s.fish\n\n", APP_NAME, APP_NAME)
+	fmt.Fprintln(w, "function __github_activity_list")
+	fmt.Fprintln(w, "    set -l tokens (commandline -opc)")
+	fmt.Fprintf(w, "    $tokens[1] completion %s $argv 2>/dev/null\n", COMPLETION_LIST_FLAG)
+	fmt.Fprintln(w, "end")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "complete -c %s -f\n", APP_NAME)
+	for _, command := range completionCommands {
+		fmt.Fprintf(w, "complete -c %s -n __fish_use_subcommand -a %s -d %s\n", APP_NAME, command.name, fishQuote(command.description))
+	}
+	fmt.Fprintf(w, "complete -c %s -n __fish_use_subcommand -a '(__github_activity_list %s)'\n", APP_NAME, COMPLETION_LIST_USERS)
+	fmt.Fprintf(w, "complete -c %s -n '__fish_seen_subcommand_from %s' -a '(__github_activity_list %s)'\n", APP_NAME, strings.Join(commandsWithArg(COMPLETE_USERS), " "), COMPLETION_LIST_USERS)
+	fmt.Fprintf(w, "complete -c %s -n '__fish_seen_subcommand_from %s' -F -a '(__github_activity_list %s)'\n", APP_NAME, strings.Join(commandsWithArg(COMPLETE_TEAMS), " "), COMPLETION_LIST_TEAMS)
+	fmt.Fprintf(w, "complete -c %s -n '__fish_seen_subcommand_from %s' -a '%s'\n", APP_NAME, strings.Join(commandsWithArg(COMPLETE_SHELLS), " "), strings.Join(COMPLETION_SHELLS, " "))
+	fmt.Fprintln(w)
+
+	shared := fmt.Sprintf("not __fish_seen_subcommand_from %s %s", FAKE_SERVER_COMMAND, COMPLETION_COMMAND)
+	for _, flag := range completionFlags {
+		condition := shared
+		if flag.commands != nil {
+			condition = "__fish_seen_subcommand_from " + strings.Join(flag.commands, " ")
+		}
+
+		option := "-l " + strings.TrimPrefix(flag.name, "--")
+		if !strings.HasPrefix(flag.name, "--") {
+			option = "-s " + strings.TrimPrefix(flag.name, "-")
+		}
+
+		switch flag.arg {
+		case COMPLETE_WORDS:
+			option += " -x -a " + fishQuote(strings.Join(flag.words, " "))
+		case COMPLETE_FILE:
+			option += " -r -F"
+		case COMPLETE_DIR:
+			option += " -x -a '(__fish_complete_directories)'"
+		case COMPLETE_PROFILES:
+			option += fmt.Sprintf(" -x -a '(__github_activity_list %s)'", COMPLETION_LIST_PROFILES)
+		case COMPLETE_TEAMS:
+			option += fmt.Sprintf(" -r -F -a '(__github_activity_list %s)'", COMPLETION_LIST_TEAMS)
+		case COMPLETE_VALUE:
+			option += " -x"
+		}
+
+		fmt.Fprintf(w, "complete -c %s -n %s %s -d %s\n", APP_NAME, fishQuote(condition), option, fishQuote(flag.description))
+	}
+}
+
+func fishQuote(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(value) + "'"
+}
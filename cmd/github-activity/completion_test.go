@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+func TestCompletionScripts(t *testing.T) {
+	for _, shell := range COMPLETION_SHELLS {
+		t.Run(shell, func(t *testing.T) {
+			script, err := captureStdout(func() error {
+				return runCompletion(context.Background(), []string{shell})
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			for _, command := range completionCommands {
+				if !strings.Contains(script, command.name) {
+					t.Errorf("Expected script to offer command %q", command.name)
+				}
+			}
+			for _, flag := range completionFlags {
+				name := flag.name
+				if shell == SHELL_FISH {
+					name = "-l " + strings.TrimPrefix(flag.name, "--")
+					if !strings.HasPrefix(flag.name, "--") {
+						name = "-s " + strings.TrimPrefix(flag.name, "-")
+					}
+				}
+				if !strings.Contains(script, name) {
+					t.Errorf("Expected script to offer flag %q", name)
+				}
+			}
+			for _, eventType := range events.EVENT_TYPES {
+				if !strings.Contains(script, eventType) {
+					t.Errorf("Expected script to offer event type %q", eventType)
+				}
+			}
+
+			interpreter, err := exec.LookPath(shell)
+			if err != nil {
+				return
+			}
+			path := writeTestFile(t, "completion."+shell, script)
+			if output, err := exec.Command(interpreter, "-n", path).CombinedOutput(); err != nil {
+				t.Errorf("Expected a valid %s script, got %v: %s", shell, err, output)
+			}
+		})
+	}
+}
+
+func TestRunCompletionUnknownShell(t *testing.T) {
+	err := runCompletion(context.Background(), []string{"tcsh"})
+	if exitCode(err) != EXIT_USAGE {
+		t.Errorf("Expected a usage error, got %v", err)
+	}
+}
+
+// Every flag that can be set through the environment should also complete
+func TestCompletionFlagsCoverEnvOptions(t *testing.T) {
+	for _, option := range envOptions {
+		for _, flag := range option.flags {
+			if !slices.ContainsFunc(completionFlags, func(completion completionFlag) bool {
+				return completion.name == flag
+			}) {
+				t.Errorf("Expected a completion for %s", flag)
+			}
+		}
+	}
+}
+
+func TestCompletionFlagNames(t *testing.T) {
+	tests := []struct {
+		command  string
+		included []string
+		excluded []string
+	}{
+		{command: "", included: []string{"-f", "--output", "--profile"}, excluded: []string{"--weeks", "--fixtures"}},
+		{command: HEATMAP_COMMAND, included: []string{"-f", "--weeks", "--until"}, excluded: []string{"--gap-days"}},
+		{command: SYNC_COMMAND, included: []string{"--team"}, excluded: []string{"--weeks"}},
+		{command: FAKE_SERVER_COMMAND, included: []string{"--fixtures", "--fail-status"}, excluded: []string{"-f", "--output"}},
+		{command: COMPLETION_COMMAND, excluded: []string{"-f"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			names := completionFlagNames(tt.command)
+			for _, name := range tt.included {
+				if !slices.Contains(names, name) {
+					t.Errorf("Expected %s in %v", name, names)
+				}
+			}
+			for _, name := range tt.excluded {
+				if slices.Contains(names, name) {
+					t.Errorf("Expected no %s in %v", name, names)
+				}
+			}
+		})
+	}
+}
+
+func TestListCompletions(t *testing.T) {
+	dir := t.TempDir()
+	archiveDir := filepath.Join(dir, "archive")
+	for _, path := range []string{
+		filepath.Join(archiveDir, ARCHIVE_USERS_DIR, "octocat", "2025-01"+ARCHIVE_SEGMENT_EXT),
+		filepath.Join(archiveDir, ARCHIVE_USERS_DIR, "hubot", ARCHIVE_UNDATED_SEGMENT+ARCHIVE_SEGMENT_EXT),
+		filepath.Join(archiveDir, ARCHIVE_ORGS_DIR, "acme", "2025-01"+ARCHIVE_SEGMENT_EXT),
+		filepath.Join(archiveDir, "ghe.example.com", ARCHIVE_USERS_DIR, "mona", "2025-01"+ARCHIVE_SEGMENT_EXT),
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	configPath := writeTestFile(t, "config.json", `{
+		"teams": {"platform": {"members": [{"username": "octocat"}]}, "infra": {"members": [{"username": "hubot"}]}},
+		"profiles": {"work": {"host": "ghe.example.com"}, "home": {}}
+	}`)
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{name: "Users", args: []string{COMPLETION_LIST_USERS, "--archive-dir", archiveDir}, expected: "hubot\noctocat\n"},
+		{name: "Enterprise users", args: []string{COMPLETION_LIST_USERS, "--archive-dir", archiveDir, "--host", "ghe.example.com"}, expected: "mona\n"},
+		{name: "Missing archive", args: []string{COMPLETION_LIST_USERS, "--archive-dir", filepath.Join(dir, "missing")}, expected: ""},
+		{name: "Teams", args: []string{COMPLETION_LIST_TEAMS, "--config", configPath}, expected: "infra\nplatform\n"},
+		{name: "Profiles", args: []string{COMPLETION_LIST_PROFILES, "--config", configPath}, expected: "home\nwork\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := captureStdout(func() error {
+				return runCompletion(context.Background(), append([]string{COMPLETION_LIST_FLAG}, tt.args...))
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if output != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/filter"
+)
+
+// fileConfig is the JSON configuration file. The top level holds defaults
+// and each named profile overrides some of them.
+type fileConfig struct {
+	configProfile
+	Profiles map[string]configProfile `json:"profiles,omitempty"`
+}
+
+// configProfile is a set of defaults for command line options. Unset fields
+// leave the built-in default alone.
+type configProfile struct {
+	PerPage     int                   `json:"per_page,omitempty"`
+	Output      string                `json:"output,omitempty"`
+	GroupBy     string                `json:"group_by,omitempty"`
+	Type        string                `json:"type,omitempty"`
+	Bots        string                `json:"bots,omitempty"`
+	BotDenylist []string              `json:"bot_denylist,omitempty"`
+	Host        string                `json:"host,omitempty"`
+	TokenEnv    string                `json:"token_env,omitempty"`
+	Timezone    string                `json:"timezone,omitempty"`
+	Timeout     string                `json:"timeout,omitempty"`
+	MaxAttempts int                   `json:"max_attempts,omitempty"`
+	RetryDelay  string                `json:"retry_delay,omitempty"`
+	Teams       map[string]teamRoster `json:"teams,omitempty"`
+}
+
+func defaultConfigPath() string {
+	if configHome := os.Getenv("XDG_CONFIG_HOME"); configHome != "" {
+		return filepath.Join(configHome, APP_NAME, CONFIG_FILE_NAME)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".", APP_NAME, CONFIG_FILE_NAME)
+	}
+	return filepath.Join(home, ".config", APP_NAME, CONFIG_FILE_NAME)
+}
+
+// loadConfig reads the configuration file at path. A missing file is an
+// empty configuration.
+func loadConfig(path string) (fileConfig, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fileConfig{}, nil
+	}
+	if err != nil {
+		return fileConfig{}, err
+	}
+
+	var config fileConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fileConfig{}, fmt.Errorf("couldn't decode %s: %v", path, err)
+	}
+
+	return config, nil
+}
+
+// profile returns the top level settings overridden by the named profile.
+// An empty name selects the top level alone.
+func (config fileConfig) profile(name string) (configProfile, error) {
+	if name == "" {
+		return config.configProfile, nil
+	}
+
+	profile, ok := config.Profiles[name]
+	if !ok {
+		names := slices.Sorted(maps.Keys(config.Profiles))
+		if len(names) < 1 {
+			return configProfile{}, fmt.Errorf("unknown profile '%s', the config file defines none", name)
+		}
+		return configProfile{}, fmt.Errorf("unknown profile '%s', expected one of: %s", name, strings.Join(names, ", "))
+	}
+
+	return config.configProfile.merge(profile), nil
+}
+
+// merge returns base with every field that is set in override replaced.
+// Teams are merged by name.
+func (base configProfile) merge(override configProfile) configProfile {
+	merged := base
+
+	if override.PerPage != 0 {
+		merged.PerPage = override.PerPage
+	}
+	if override.Output != "" {
+		merged.Output = override.Output
+	}
+	if override.GroupBy != "" {
+		merged.GroupBy = override.GroupBy
+	}
+	if override.Type != "" {
+		merged.Type = override.Type
+	}
+	if override.Bots != "" {
+		merged.Bots = override.Bots
+	}
+	if override.BotDenylist != nil {
+		merged.BotDenylist = override.BotDenylist
+	}
+	if override.Host != "" {
+		merged.Host = override.Host
+	}
+	if override.TokenEnv != "" {
+		merged.TokenEnv = override.TokenEnv
+	}
+	if override.Timezone != "" {
+		merged.Timezone = override.Timezone
+	}
+	if override.Timeout != "" {
+		merged.Timeout = override.Timeout
+	}
+	if override.MaxAttempts != 0 {
+		merged.MaxAttempts = override.MaxAttempts
+	}
+	if override.RetryDelay != "" {
+		merged.RetryDelay = override.RetryDelay
+	}
+	if override.Teams != nil {
+		merged.Teams = maps.Clone(base.Teams)
+		if merged.Teams == nil {
+			merged.Teams = map[string]teamRoster{}
+		}
+		maps.Copy(merged.Teams, override.Teams)
+	}
+
+	return merged
+}
+
+// apply sets the options the profile has values for. Host, token and retry
+// settings are read from opts.config where the API client is set up.
+func (profile configProfile) apply(opts *cliOptions) error {
+	if profile.PerPage != 0 {
+		if profile.PerPage < 0 {
+			return fmt.Errorf("per_page: %d is not a positive number", profile.PerPage)
+		}
+		opts.perPage = strconv.Itoa(profile.PerPage)
+	}
+	if profile.Output != "" {
+		opts.output = profile.Output
+	}
+	if profile.GroupBy != "" {
+		opts.groupBy = profile.GroupBy
+	}
+	if profile.Type != "" {
+		opts.filters.Type = profile.Type
+	}
+
+	switch profile.Bots {
+	case "", CONFIG_BOTS_ALL:
+	case filter.BOT_FILTER_EXCLUDE, filter.BOT_FILTER_ONLY:
+		opts.filters.Bots = profile.Bots
+	default:
+		return fmt.Errorf("bots: unknown bot filter '%s', expected one of: all, exclude, only", profile.Bots)
+	}
+	if profile.BotDenylist != nil {
+		opts.filters.BotDenylist = append(slices.Clone(opts.filters.BotDenylist), profile.BotDenylist...)
+	}
+
+	if profile.Timezone != "" {
+		location, err := time.LoadLocation(profile.Timezone)
+		if err != nil {
+			return fmt.Errorf("timezone: %v", err)
+		}
+		opts.location = location
+	}
+
+	if profile.Timeout != "" {
+		timeout, err := time.ParseDuration(profile.Timeout)
+		if err != nil || timeout < 0 {
+			return fmt.Errorf("timeout: %v is not a duration like 30s or 2m", profile.Timeout)
+		}
+		opts.timeout = timeout
+	}
+
+	if profile.MaxAttempts < 0 {
+		return fmt.Errorf("max_attempts: %d is not a positive number", profile.MaxAttempts)
+	}
+	if profile.RetryDelay != "" {
+		if delay, err := time.ParseDuration(profile.RetryDelay); err != nil || delay < 0 {
+			return fmt.Errorf("retry_delay: %v is not a duration like 500ms or 2s", profile.RetryDelay)
+		}
+	}
+
+	for name, roster := range profile.Teams {
+		if _, err := roster.validate(name); err != nil {
+			return fmt.Errorf("team '%s': %v", name, err)
+		}
+	}
+
+	opts.config = profile
+	return nil
+}
+
+// teamRoster returns the roster stored in the file at ref, or failing that
+// the team of that name from the config file.
+func (opts cliOptions) teamRoster(ref string) (teamRoster, error) {
+	if roster, ok := opts.config.Teams[ref]; ok {
+		if _, err := os.Stat(ref); errors.Is(err, fs.ErrNotExist) {
+			return roster.validate(ref)
+		}
+	}
+	return loadTeamRoster(ref)
+}
+
+// effectiveConfig is what the config command prints: the settings that
+// result from the defaults, the config file, the environment and the flags.
+type effectiveConfig struct {
+	File        string `json:"file"`
+	FileFound   bool   `json:"file_found"`
+	Profile     string `json:"profile,omitempty"`
+	APIURL      string `json:"api_url"`
+	TokenSource string `json:"token_source"`
+	configProfile
+}
+
+func runConfig(ctx context.Context, args []string) error {
+	opts, err := parseOptions(args)
+	if err != nil {
+		return usageError("parsing %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(opts.effectiveConfig()); err != nil {
+		return fmt.Errorf("printing config: %w", err)
+	}
+	return nil
+}
+
+func (opts cliOptions) effectiveConfig() effectiveConfig {
+	_, err := os.Stat(opts.configPath)
+	perPage, _ := strconv.Atoi(opts.perPage)
+
+	effective := effectiveConfig{
+		File:        opts.configPath,
+		FileFound:   err == nil,
+		Profile:     opts.profile,
+		APIURL:      opts.client.BaseURL,
+		TokenSource: opts.credentials.source,
+		configProfile: configProfile{
+			PerPage:     perPage,
+			Output:      opts.output,
+			GroupBy:     opts.groupBy,
+			Type:        opts.filters.Type,
+			Bots:        cmp.Or(opts.filters.Bots, CONFIG_BOTS_ALL),
+			BotDenylist: opts.filters.BotDenylist,
+			Host:        opts.host,
+			TokenEnv:    opts.config.TokenEnv,
+			Timezone:    opts.location.String(),
+			Timeout:     opts.timeout.String(),
+			MaxAttempts: opts.client.Retry.MaxAttempts,
+			RetryDelay:  opts.client.Retry.BaseDelay.String(),
+			Teams:       opts.config.Teams,
+		},
+	}
+	if effective.TokenSource == "" {
+		effective.TokenSource = CONFIG_NO_TOKEN
+	}
+
+	return effective
+}
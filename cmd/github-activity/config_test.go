@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/filter"
+	"github.com/dmitriy-zverev/github-activity/render"
+)
+
+const testConfig = `{
+  "per_page": 50,
+  "output": "json",
+  "bots": "exclude",
+  "timezone": "Europe/Berlin",
+  "teams": {
+    "platform": {"members": [{"username": "octocat", "name": "Mona"}]}
+  },
+  "profiles": {
+    "work": {
+      "host": "ghe.example.com",
+      "token_env": "WORK_TOKEN",
+      "per_page": 100,
+      "max_attempts": 5,
+      "retry_delay": "2s",
+      "teams": {
+        "infra": {"name": "Infrastructure", "members": [{"username": "hubot"}]}
+      }
+    },
+    "quiet": {"type": "PushEvent", "bots": "all", "timeout": "5s"}
+  }
+}`
+
+func TestConfigProfile(t *testing.T) {
+	var config fileConfig
+	if err := json.Unmarshal([]byte(testConfig), &config); err != nil {
+		t.Fatalf("Couldn't decode test config: %v", err)
+	}
+
+	base, err := config.profile("")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if base.PerPage != 50 || base.Host != "" || len(base.Teams) != 1 {
+		t.Errorf("Unexpected top level settings: %+v", base)
+	}
+
+	work, err := config.profile("work")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if work.PerPage != 100 || work.Output != "json" || work.Host != "ghe.example.com" || work.TokenEnv != "WORK_TOKEN" {
+		t.Errorf("Expected the profile over the top level settings, got %+v", work)
+	}
+	if len(work.Teams) != 2 || len(config.Teams) != 1 {
+		t.Errorf("Expected the profile's teams added to a copy of the top level ones, got %v and %v", work.Teams, config.Teams)
+	}
+
+	if _, err := config.profile("home"); err == nil || !strings.Contains(err.Error(), "quiet, work") {
+		t.Errorf("Expected an unknown profile error listing the profiles, got %v", err)
+	}
+}
+
+func TestConfigApply(t *testing.T) {
+	tests := []struct {
+		name        string
+		profile     configProfile
+		expectError bool
+	}{
+		{name: "Empty", profile: configProfile{}},
+		{name: "Bad bot filter", profile: configProfile{Bots: "some"}, expectError: true},
+		{name: "Bad timezone", profile: configProfile{Timezone: "Mars/Olympus"}, expectError: true},
+		{name: "Bad timeout", profile: configProfile{Timeout: "soon"}, expectError: true},
+		{name: "Bad retry delay", profile: configProfile{RetryDelay: "-1s"}, expectError: true},
+		{name: "Negative per page", profile: configProfile{PerPage: -5}, expectError: true},
+		{name: "Team without members", profile: configProfile{Teams: map[string]teamRoster{"empty": {}}}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := cliOptions{location: time.Local}
+			err := tt.profile.apply(&opts)
+			if tt.expectError != (err != nil) {
+				t.Errorf("Expected error %v, got %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestParseOptionsConfig(t *testing.T) {
+	path := writeTestFile(t, "config.json", testConfig)
+	t.Setenv("WORK_TOKEN", "work-token")
+
+	opts, err := parseOptions([]string{"--config", path})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.perPage != "50" || opts.output != render.OUTPUT_JSON || opts.filters.Bots != filter.BOT_FILTER_EXCLUDE {
+		t.Errorf("Expected the config defaults, got per page %s, output %s, bots %q", opts.perPage, opts.output, opts.filters.Bots)
+	}
+	if opts.location.String() != "Europe/Berlin" {
+		t.Errorf("Expected the config timezone, got %s", opts.location)
+	}
+
+	opts, err = parseOptions([]string{"--config", path, "--profile", "work", "-n", "10", "-o", "text"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.perPage != "10" || opts.output != render.OUTPUT_TEXT {
+		t.Errorf("Expected flags over the profile, got per page %s, output %s", opts.perPage, opts.output)
+	}
+	if opts.client.BaseURL != "https://ghe.example.com/api/v3" || opts.client.Token != "work-token" {
+		t.Errorf("Expected the profile's host and token, got %q, %q", opts.client.BaseURL, opts.client.Token)
+	}
+	if opts.client.Retry.MaxAttempts != 5 || opts.client.Retry.BaseDelay != 2*time.Second {
+		t.Errorf("Expected the profile's retry policy, got %+v", opts.client.Retry)
+	}
+
+	t.Setenv("GH_HOST", "ghe.other.org")
+	opts, err = parseOptions([]string{"--config", path, "--profile", "work"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.host != "ghe.other.org" {
+		t.Errorf("Expected GH_HOST over the profile's host, got %q", opts.host)
+	}
+
+	if _, err := parseOptions([]string{"--config", path, "--profile", "home"}); err == nil {
+		t.Error("Expected error for an unknown profile")
+	}
+	if _, err := parseOptions([]string{"--config", writeTestFile(t, "broken.json", "{")}); err == nil {
+		t.Error("Expected error for a malformed config file")
+	}
+	if _, err := parseOptions([]string{"--config", filepath.Join(t.TempDir(), "missing.json")}); err != nil {
+		t.Errorf("Expected a missing config file to be fine, got %v", err)
+	}
+}
+
+func TestTeamRosterFromConfig(t *testing.T) {
+	path := writeTestFile(t, "config.json", testConfig)
+	opts, err := parseOptions([]string{"--config", path, "--profile", "work"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	roster, err := opts.teamRoster("platform")
+	if err != nil || roster.Name != "platform" || roster.usernames()[0] != "octocat" {
+		t.Errorf("Expected the configured platform team, got %+v, %v", roster, err)
+	}
+	roster, err = opts.teamRoster("infra")
+	if err != nil || roster.Name != "Infrastructure" {
+		t.Errorf("Expected the profile's infra team, got %+v, %v", roster, err)
+	}
+
+	file := writeTestFile(t, "platform", `{"name":"From file","members":[{"username":"hubot"}]}`)
+	opts.config.Teams[file] = opts.config.Teams["platform"]
+	if roster, err := opts.teamRoster(file); err != nil || roster.Name != "From file" {
+		t.Errorf("Expected an existing roster file to win, got %+v, %v", roster, err)
+	}
+
+	if _, err := opts.teamRoster("nobody"); err == nil {
+		t.Error("Expected error for an unknown team")
+	}
+}
+
+func TestRunConfig(t *testing.T) {
+	path := writeTestFile(t, "config.json", testConfig)
+	t.Setenv("WORK_TOKEN", "work-token")
+
+	output, err := captureStdout(func() error {
+		return runConfig(t.Context(), []string{"--config", path, "--profile", "work", "--timeout", "1m"})
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var effective effectiveConfig
+	if err := json.Unmarshal([]byte(output), &effective); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+	}
+	if !effective.FileFound || effective.File != path || effective.Profile != "work" {
+		t.Errorf("Unexpected config file details: %+v", effective)
+	}
+	if effective.PerPage != 100 || effective.Host != "ghe.example.com" || effective.Timeout != "1m0s" || effective.Bots != "exclude" {
+		t.Errorf("Unexpected effective settings: %+v", effective.configProfile)
+	}
+	if effective.TokenSource != "$WORK_TOKEN" || strings.Contains(output, "work-token") {
+		t.Errorf("Expected the token source but never the token, got:\n%s", output)
+	}
+}
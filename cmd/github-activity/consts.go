@@ -0,0 +1,133 @@
+package main
+
+import (
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/filter"
+	"github.com/dmitriy-zverev/github-activity/render"
+)
+
+const (
+	DEFAULT_PAGE_NUM        = "1"
+	DEFAULT_PER_PAGE_EVENTS = "30"
+	DEFAULT_FILTER_TYPE     = filter.ANY_TYPE
+	DEFAULT_BOT_FILTER      = filter.BOT_FILTER_NONE
+	DEFAULT_OUTPUT_FORMAT   = render.OUTPUT_TEXT
+	DEFAULT_GROUP_BY        = render.GROUP_BY_NONE
+	DEFAULT_HEATMAP_WEEKS   = 13
+	DEFAULT_GAP_DAYS        = 3
+	DEFAULT_TEAM_NAME       = "team"
+	DEFAULT_TIMEOUT         = 30 * time.Second
+
+	DEFAULT_FAKE_SERVER_ADDR        = "localhost:8080"
+	FAKE_SERVER_READ_HEADER_TIMEOUT = 10 * time.Second
+)
+
+const (
+	HISTOGRAM_BAR_WIDTH = 40
+)
+
+const (
+	STATS_COMMAND       = "stats"
+	HEATMAP_COMMAND     = "heatmap"
+	HISTOGRAM_COMMAND   = "histogram"
+	STREAKS_COMMAND     = "streaks"
+	COMPARE_COMMAND     = "compare"
+	TEAM_COMMAND        = "team"
+	SYNC_COMMAND        = "sync"
+	FAKE_SERVER_COMMAND = "fake-server"
+	CONFIG_COMMAND      = "config"
+	COMPLETION_COMMAND  = "completion"
+)
+
+var (
+	HELP_COMMANDS = []string{"help", "-h", "--help"}
+)
+
+const (
+	STDIN_INPUT = "-"
+)
+
+const (
+	SHELL_BASH = "bash"
+	SHELL_ZSH  = "zsh"
+	SHELL_FISH = "fish"
+)
+
+var (
+	COMPLETION_SHELLS = []string{SHELL_BASH, SHELL_ZSH, SHELL_FISH}
+)
+
+const (
+	COMPLETION_LIST_FLAG     = "--list"
+	COMPLETION_LIST_USERS    = "users"
+	COMPLETION_LIST_TEAMS    = "teams"
+	COMPLETION_LIST_PROFILES = "profiles"
+)
+
+// What a positional argument or flag value completes to
+const (
+	COMPLETE_NONE = iota
+	COMPLETE_VALUE
+	COMPLETE_WORDS
+	COMPLETE_FILE
+	COMPLETE_DIR
+	COMPLETE_USERS
+	COMPLETE_TEAMS
+	COMPLETE_PROFILES
+	COMPLETE_SHELLS
+)
+
+const (
+	EXIT_OK          = 0
+	EXIT_FAILURE     = 1
+	EXIT_USAGE       = 2
+	EXIT_NOT_FOUND   = 3
+	EXIT_AUTH        = 4
+	EXIT_RATE_LIMIT  = 5
+	EXIT_NETWORK     = 6
+	EXIT_NO_RESULTS  = 7
+	EXIT_INTERRUPTED = 130
+)
+
+const (
+	HOST_ENV      = "GH_HOST"
+	GH_HOSTS_FILE = "hosts.yml"
+)
+
+const (
+	ENV_PREFIX            = "GITHUB_ACTIVITY_"
+	HOST_TOKEN_ENV_PREFIX = ENV_PREFIX + "TOKEN_"
+)
+
+const (
+	CONFIG_FILE_NAME = "config.json"
+	CONFIG_BOTS_ALL  = "all"
+	CONFIG_NO_TOKEN  = "none"
+)
+
+var (
+	GITHUB_TOKEN_VARS     = []string{"GH_TOKEN", "GITHUB_TOKEN"}
+	ENTERPRISE_TOKEN_VARS = []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+)
+
+const (
+	APP_NAME                = "github-activity"
+	ARCHIVE_DIR_NAME        = "archive"
+	ARCHIVE_SEGMENT_EXT     = ".jsonl"
+	ARCHIVE_SEGMENT_FORMAT  = "2006-01"
+	ARCHIVE_UNDATED_SEGMENT = "undated"
+	ARCHIVE_USERS_DIR       = "users"
+	ARCHIVE_ORGS_DIR        = "orgs"
+	ARCHIVE_MAX_LINE_SIZE   = 10 * 1024 * 1024
+)
+
+const (
+	DAY_FORMAT       = "2006-01-02"
+	DATE_TIME_FORMAT = "2006-01-02 15:04"
+)
+
+const (
+	COLOR_RESET   = "\033[0m"
+	HEATMAP_BLOCK = "■"
+)
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// envOption is a GITHUB_ACTIVITY_* variable standing in for a flag. The
+// variable only applies when none of flags is on the command line, so a flag
+// or its counterpart always wins over the environment. The first flag is the
+// one the variable sets. A boolean variable set to false adds the reset flag
+// instead, if it has one, to undo a default from the config file.
+type envOption struct {
+	name    string
+	flags   []string
+	boolean bool
+	reset   string
+}
+
+var envOptions = []envOption{
+	{name: "PAGE", flags: []string{"-p"}},
+	{name: "PER_PAGE", flags: []string{"-n"}},
+	{name: "FILTER", flags: []string{"-f"}},
+	{name: "OUTPUT", flags: []string{"--output", "-o"}},
+	{name: "GROUP_BY", flags: []string{"--group-by"}},
+	{name: "VERBOSE", flags: []string{"--verbose", "-v"}, boolean: true},
+	{name: "INTERACTIVE", flags: []string{"--interactive"}, boolean: true},
+	{name: "NO_COLLAPSE", flags: []string{"--no-collapse"}, boolean: true},
+	{name: "GREP", flags: []string{"--grep"}},
+	{name: "REGEX", flags: []string{"--regex"}, boolean: true},
+	{name: "IGNORE_CASE", flags: []string{"--ignore-case"}, boolean: true},
+	{name: "NO_BOTS", flags: []string{"--no-bots", "--only-bots"}, boolean: true, reset: "--all-bots"},
+	{name: "ONLY_BOTS", flags: []string{"--only-bots", "--no-bots"}, boolean: true, reset: "--all-bots"},
+	{name: "BOT_DENYLIST", flags: []string{"--bot-denylist"}},
+	{name: "TZ", flags: []string{"--tz"}},
+	{name: "OFFLINE", flags: []string{"--offline", "--input"}, boolean: true},
+	{name: "ARCHIVE_DIR", flags: []string{"--archive-dir"}},
+	{name: "INPUT", flags: []string{"--input", "--offline"}},
+	{name: "RECORD", flags: []string{"--record", "--replay"}},
+	{name: "REPLAY", flags: []string{"--replay", "--record"}},
+	{name: "HOST", flags: []string{"--host"}},
+	{name: "CA_BUNDLE", flags: []string{"--ca-bundle"}},
+	{name: "API_URL", flags: []string{"--api-url"}},
+	{name: "API_URL_TOKEN", flags: []string{"--api-url-token"}, boolean: true},
+	{name: "TIMEOUT", flags: []string{"--timeout"}},
+	{name: "MAX_ATTEMPTS", flags: []string{"--max-attempts"}},
+	{name: "RETRY_DELAY", flags: []string{"--retry-delay"}},
+	{name: "CONFIG", flags: []string{"--config"}},
+	{name: "PROFILE", flags: []string{"--profile"}},
+	{name: "WEEKS", flags: []string{"--weeks"}},
+	{name: "UNTIL", flags: []string{"--until"}},
+	{name: "GAP_DAYS", flags: []string{"--gap-days"}},
+	{name: "TEAM", flags: []string{"--team"}},
+	{name: "ORG", flags: []string{"--org"}},
+	{name: "FIXTURES", flags: []string{"--fixtures"}},
+	{name: "ADDR", flags: []string{"--addr"}},
+	{name: "RATE_LIMIT", flags: []string{"--rate-limit"}},
+	{name: "FAIL_EVERY", flags: []string{"--fail-every"}},
+	{name: "FAIL_STATUS", flags: []string{"--fail-status"}},
+}
+
+// withEnvOptions appends the flags set through GITHUB_ACTIVITY_* variables to
+// args. Options given on the command line keep their value, and since the
+// config file is applied before any flag, the environment overrides it.
+func withEnvOptions(args []string, getenv func(string) string) ([]string, error) {
+	withEnv := slices.Clone(args)
+
+	for _, option := range envOptions {
+		name := ENV_PREFIX + option.name
+		value := getenv(name)
+		if value == "" || slices.ContainsFunc(option.flags, func(flag string) bool {
+			return slices.Contains(args, flag)
+		}) {
+			continue
+		}
+
+		if !option.boolean {
+			withEnv = append(withEnv, option.flags[0], value)
+			continue
+		}
+
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return args, fmt.Errorf("%s: %v is not true or false", name, value)
+		}
+		switch {
+		case enabled:
+			withEnv = append(withEnv, option.flags[0])
+		case option.reset != "" && !slices.Contains(withEnv, option.reset):
+			withEnv = append(withEnv, option.reset)
+		}
+	}
+
+	return withEnv, nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dmitriy-zverev/github-activity/filter"
+)
+
+func TestWithEnvOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		env         map[string]string
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "No variables",
+			args:     []string{"octocat", "-n", "10"},
+			expected: []string{"octocat", "-n", "10"},
+		},
+		{
+			name:     "Value options",
+			args:     []string{"octocat"},
+			env:      map[string]string{"GITHUB_ACTIVITY_PER_PAGE": "50", "GITHUB_ACTIVITY_OUTPUT": "json"},
+			expected: []string{"octocat", "-n", "50", "--output", "json"},
+		},
+		{
+			name:     "Flag wins",
+			args:     []string{"octocat", "-o", "text", "-n", "5"},
+			env:      map[string]string{"GITHUB_ACTIVITY_PER_PAGE": "50", "GITHUB_ACTIVITY_OUTPUT": "json"},
+			expected: []string{"octocat", "-o", "text", "-n", "5"},
+		},
+		{
+			name:     "Boolean options",
+			args:     []string{"stats", "octocat"},
+			env:      map[string]string{"GITHUB_ACTIVITY_VERBOSE": "1", "GITHUB_ACTIVITY_NO_COLLAPSE": "false"},
+			expected: []string{"stats", "octocat", "--verbose"},
+		},
+		{
+			name:     "Counterpart flag wins",
+			args:     []string{"octocat", "--only-bots", "--input", "events.json"},
+			env:      map[string]string{"GITHUB_ACTIVITY_NO_BOTS": "true", "GITHUB_ACTIVITY_OFFLINE": "true"},
+			expected: []string{"octocat", "--only-bots", "--input", "events.json"},
+		},
+		{
+			name:     "False bot filter resets the config",
+			args:     []string{"octocat"},
+			env:      map[string]string{"GITHUB_ACTIVITY_NO_BOTS": "0", "GITHUB_ACTIVITY_ONLY_BOTS": "false"},
+			expected: []string{"octocat", "--all-bots"},
+		},
+		{
+			name:     "Explicit bot filter wins over reset",
+			args:     []string{"octocat"},
+			env:      map[string]string{"GITHUB_ACTIVITY_NO_BOTS": "false", "GITHUB_ACTIVITY_ONLY_BOTS": "true"},
+			expected: []string{"octocat", "--all-bots", "--only-bots"},
+		},
+		{
+			name:        "Invalid boolean",
+			args:        []string{"octocat"},
+			env:         map[string]string{"GITHUB_ACTIVITY_OFFLINE": "yes please"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := withEnvOptions(tt.args, func(name string) string { return tt.env[name] })
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for %v", tt.env)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if fmt.Sprint(args) != fmt.Sprint(tt.expected) {
+				t.Errorf("Expected args %v, got %v", tt.expected, args)
+			}
+		})
+	}
+}
+
+func TestEnvPrecedence(t *testing.T) {
+	config := writeTestFile(t, "config.json", `{"per_page": 50, "output": "json", "timeout": "10s"}`)
+	env := map[string]string{
+		"GITHUB_ACTIVITY_CONFIG":   config,
+		"GITHUB_ACTIVITY_PER_PAGE": "70",
+		"GITHUB_ACTIVITY_OUTPUT":   "text",
+	}
+
+	args, err := withEnvOptions([]string{"-n", "90"}, func(name string) string { return env[name] })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	opts, err := parseOptions(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// flag > env > config file > default
+	if opts.perPage != "90" || opts.output != "text" || opts.timeout.String() != "10s" || opts.page != DEFAULT_PAGE_NUM {
+		t.Errorf("Unexpected precedence: per page %s, output %s, timeout %s, page %s", opts.perPage, opts.output, opts.timeout, opts.page)
+	}
+
+	env["GITHUB_ACTIVITY_CONFIG"] = writeTestFile(t, "bots.json", `{"bots": "exclude"}`)
+	env["GITHUB_ACTIVITY_NO_BOTS"] = "false"
+	args, err = withEnvOptions([]string{}, func(name string) string { return env[name] })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	opts, err = parseOptions(args)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.filters.Bots != filter.BOT_FILTER_NONE {
+		t.Errorf("Expected a false GITHUB_ACTIVITY_NO_BOTS to undo the config's bot filter, got %q", opts.filters.Bots)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/client"
+	"github.com/dmitriy-zverev/github-activity/filter"
+)
+
+// errUsage makes run print the help text and exit with EXIT_USAGE.
+var errUsage = errors.New("usage")
+
+// exitError is an error that decides the exit status itself rather than
+// leaving it to the failure behind it.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (err exitError) Error() string {
+	return err.err.Error()
+}
+
+func (err exitError) Unwrap() error {
+	return err.err
+}
+
+// timeoutError is the cause of a fetch running out of --timeout. It matches
+// context.DeadlineExceeded so it is reported as a network failure.
+type timeoutError struct {
+	timeout time.Duration
+}
+
+func (err timeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s", err.timeout)
+}
+
+func (err timeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+func usageError(format string, args ...any) error {
+	return exitError{code: EXIT_USAGE, err: fmt.Errorf(format, args...)}
+}
+
+func filterError(err error) error {
+	var noResult filter.NoResultError
+	if errors.As(err, &noResult) {
+		return exitError{code: EXIT_NO_RESULTS, err: fmt.Errorf("no result for %s", noResult.Filter)}
+	}
+
+	return fmt.Errorf("filtering user activity: %w", err)
+}
+
+// exitCode picks the exit status for err from the API, network or filter
+// failure behind it.
+func exitCode(err error) int {
+	var exit exitError
+	if errors.As(err, &exit) {
+		return exit.code
+	}
+
+	var status client.StatusError
+	if errors.As(err, &status) {
+		switch {
+		case status.RateLimited:
+			return EXIT_RATE_LIMIT
+		case status.StatusCode == http.StatusUnauthorized || status.StatusCode == http.StatusForbidden:
+			return EXIT_AUTH
+		case status.StatusCode == http.StatusNotFound:
+			return EXIT_NOT_FOUND
+		default:
+			return EXIT_FAILURE
+		}
+	}
+
+	// A syscall.Errno is a net.Error too, so file errors would match that
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &opErr) || errors.As(err, &dnsErr) {
+		return EXIT_NETWORK
+	}
+
+	return EXIT_FAILURE
+}
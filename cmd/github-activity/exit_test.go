@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/client"
+	"github.com/dmitriy-zverev/github-activity/filter"
+)
+
+func TestExitCode(t *testing.T) {
+	networkErr := &url.Error{Op: "Get", URL: "https://api.github.com", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}
+	dnsErr := &url.Error{Op: "Get", URL: "https://ghe.invalid", Err: &net.DNSError{Err: "no such host", Name: "ghe.invalid"}}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "Usage", err: usageError("parsing %v", "page"), expected: EXIT_USAGE},
+		{name: "Not found", err: client.StatusError{StatusCode: 404}, expected: EXIT_NOT_FOUND},
+		{name: "Unauthorized", err: client.StatusError{StatusCode: 401}, expected: EXIT_AUTH},
+		{name: "Forbidden", err: client.StatusError{StatusCode: 403}, expected: EXIT_AUTH},
+		{name: "Rate limited", err: client.StatusError{StatusCode: 403, RateLimited: true}, expected: EXIT_RATE_LIMIT},
+		{name: "Server error", err: client.StatusError{StatusCode: 502}, expected: EXIT_FAILURE},
+		{name: "Network", err: networkErr, expected: EXIT_NETWORK},
+		{name: "DNS", err: dnsErr, expected: EXIT_NETWORK},
+		{name: "Missing file", err: &fs.PathError{Op: "open", Path: "events.json", Err: syscall.ENOENT}, expected: EXIT_FAILURE},
+		{name: "Timeout", err: timeoutError{timeout: time.Second}, expected: EXIT_NETWORK},
+		{name: "No results", err: filterError(filter.NoResultError{Filter: "'Release' filter"}), expected: EXIT_NO_RESULTS},
+		{name: "Other", err: errors.New("disk full"), expected: EXIT_FAILURE},
+		{
+			name:     "Wrapped",
+			err:      fmt.Errorf("fetching user activity: %w", client.StatusError{StatusCode: 404}),
+			expected: EXIT_NOT_FOUND,
+		},
+		{
+			name:     "Joined",
+			err:      fmt.Errorf("syncing activity: %w", errors.Join(errors.New("disk full"), client.StatusError{StatusCode: 429, RateLimited: true})),
+			expected: EXIT_RATE_LIMIT,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := exitCode(tt.err); code != tt.expected {
+				t.Errorf("Expected exit code %d for %v, got %d", tt.expected, tt.err, code)
+			}
+		})
+	}
+
+	if !errors.Is(timeoutError{timeout: time.Second}, context.DeadlineExceeded) {
+		t.Error("Expected a timeout to match context.DeadlineExceeded")
+	}
+}
+
+func TestRun(t *testing.T) {
+	path := writeTestFile(t, "events.json", testEventsArray)
+
+	tests := []struct {
+		name           string
+		args           []string
+		env            map[string]string
+		expectedCode   int
+		expectedStdout bool
+	}{
+		{name: "No arguments", args: []string{}, expectedCode: EXIT_USAGE},
+		{name: "Help", args: []string{"--help"}, expectedCode: EXIT_OK, expectedStdout: true},
+		{name: "Missing username", args: []string{"stats"}, expectedCode: EXIT_USAGE},
+		{name: "Invalid option", args: []string{"octocat", "-p", "first"}, expectedCode: EXIT_USAGE},
+		{name: "Organization online", args: []string{"--org", "acme"}, expectedCode: EXIT_USAGE},
+		{name: "Events", args: []string{"--input", path}, expectedCode: EXIT_OK, expectedStdout: true},
+		{name: "No result after filter", args: []string{"--input", path, "-f", "Release"}, expectedCode: EXIT_NO_RESULTS},
+		{name: "Missing input", args: []string{"--input", path + ".missing"}, expectedCode: EXIT_FAILURE},
+		{name: "Input from environment", args: []string{}, env: map[string]string{"GITHUB_ACTIVITY_INPUT": path}, expectedCode: EXIT_OK, expectedStdout: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			var code int
+			output, _ := captureStdout(func() error {
+				code = run(tt.args)
+				return nil
+			})
+
+			if code != tt.expectedCode {
+				t.Errorf("Expected exit code %d, got %d", tt.expectedCode, code)
+			}
+			if tt.expectedStdout != (output != "") {
+				t.Errorf("Expected output on stdout: %v, got %q", tt.expectedStdout, output)
+			}
+		})
+	}
+}
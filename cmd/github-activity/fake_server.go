@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dmitriy-zverev/github-activity/fakeserver"
+)
+
+func runFakeServer(ctx context.Context, args []string) error {
+	fixtures, ok := argValue(args, "--fixtures")
+	if !ok {
+		return errUsage
+	}
+
+	server := fakeserver.New(fixtures)
+
+	addr := DEFAULT_FAKE_SERVER_ADDR
+	if value, ok := argValue(args, "--addr"); ok {
+		addr = value
+	}
+
+	for _, option := range []struct {
+		name   string
+		target *int
+	}{
+		{"--rate-limit", &server.RateLimit},
+		{"--fail-every", &server.FailEvery},
+		{"--fail-status", &server.FailStatus},
+	} {
+		value, ok := argValue(args, option.name)
+		if !ok {
+			continue
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 0 {
+			return usageError("parsing %s: %v is not a non-negative number", strings.TrimPrefix(option.name, "--"), value)
+		}
+		*option.target = number
+	}
+	if http.StatusText(server.FailStatus) == "" || server.FailStatus < 400 {
+		return usageError("parsing fail-status: %d is not an HTTP error status", server.FailStatus)
+	}
+
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           server,
+		ReadHeaderTimeout: FAKE_SERVER_READ_HEADER_TIMEOUT,
+	}
+	go func() {
+		<-ctx.Done()
+		httpServer.Shutdown(context.Background())
+	}()
+
+	fmt.Fprintf(os.Stderr, "Serving fake GitHub API from %s on http://%s\n", fixtures, addr)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("running fake server: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/dmitriy-zverev/github-activity/client"
+)
+
+// ghHosts is what github-activity takes from the gh CLI's hosts.yml: the
+// hosts gh is logged in to, in file order, and the tokens stored for them.
+type ghHosts struct {
+	path   string
+	hosts  []string
+	tokens map[string]string
+}
+
+// yamlEntry is a scalar value in a YAML document with the keys of the
+// mappings leading to it.
+type yamlEntry struct {
+	path  []string
+	value string
+}
+
+// ghHostsPath returns where gh keeps hosts.yml, honoring GH_CONFIG_DIR and
+// XDG_CONFIG_HOME like gh itself does.
+func ghHostsPath() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return filepath.Join(dir, GH_HOSTS_FILE)
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh", GH_HOSTS_FILE)
+	}
+	if dir := os.Getenv("AppData"); runtime.GOOS == "windows" && dir != "" {
+		return filepath.Join(dir, "GitHub CLI", GH_HOSTS_FILE)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gh", GH_HOSTS_FILE)
+}
+
+// loadGHHosts reads the hosts.yml at path. A missing file means gh isn't set
+// up and is not an error.
+func loadGHHosts(path string) (ghHosts, error) {
+	if path == "" {
+		return ghHosts{}, nil
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ghHosts{}, nil
+	}
+	if err != nil {
+		return ghHosts{}, err
+	}
+	defer file.Close()
+
+	entries, err := parseYAML(file)
+	if err != nil {
+		return ghHosts{}, err
+	}
+
+	gh := ghHosts{path: path, tokens: map[string]string{}}
+	activeUsers := map[string]string{}
+	userTokens := map[string]string{}
+	for _, entry := range entries {
+		host := client.NormalizeHost(entry.path[0])
+		if len(entry.path) == 1 && entry.value == "" {
+			gh.hosts = append(gh.hosts, host)
+			continue
+		}
+
+		switch {
+		case len(entry.path) == 2 && entry.path[1] == "oauth_token":
+			gh.tokens[host] = entry.value
+		case len(entry.path) == 2 && entry.path[1] == "user":
+			activeUsers[host] = entry.value
+		case len(entry.path) == 4 && entry.path[1] == "users" && entry.path[3] == "oauth_token":
+			userTokens[host+"/"+entry.path[2]] = entry.value
+		}
+	}
+
+	// gh 2.40+ keeps one token per account under users and marks the active
+	// account with user
+	for host, user := range activeUsers {
+		if token := userTokens[host+"/"+user]; gh.tokens[host] == "" && token != "" {
+			gh.tokens[host] = token
+		}
+	}
+
+	return gh, nil
+}
+
+// defaultHost is the host gh would talk to without GH_HOST: github.com when
+// logged in there or nowhere, and otherwise the first configured host.
+func (gh ghHosts) defaultHost() string {
+	if len(gh.hosts) == 0 || slices.Contains(gh.hosts, client.DEFAULT_HOST) {
+		return client.DEFAULT_HOST
+	}
+	return gh.hosts[0]
+}
+
+// parseYAML reads the block mappings of a YAML document, which is all gh
+// writes to hosts.yml. Sequences, flow collections, anchors and multi-line
+// scalars are skipped rather than understood.
+func parseYAML(r io.Reader) ([]yamlEntry, error) {
+	type level struct {
+		indent int
+		key    string
+	}
+
+	var entries []yamlEntry
+	var stack []level
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		content := strings.TrimLeft(line, " ")
+		if content == "" || strings.HasPrefix(content, "#") || strings.HasPrefix(content, "-") || content == "---" {
+			continue
+		}
+		indent := len(line) - len(content)
+
+		// Hosts may carry a port, so only ": " or a trailing colon ends a key
+		key, value, ok := strings.Cut(content, ": ")
+		if !ok {
+			if !strings.HasSuffix(content, ":") {
+				continue
+			}
+			key, value = strings.TrimSuffix(content, ":"), ""
+		}
+
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		path := make([]string, 0, len(stack)+1)
+		for _, parent := range stack {
+			path = append(path, parent.key)
+		}
+		path = append(path, yamlScalar(key))
+
+		value = yamlScalar(value)
+		if value == "" {
+			stack = append(stack, level{indent: indent, key: yamlScalar(key)})
+		}
+		entries = append(entries, yamlEntry{path: path, value: value})
+	}
+
+	return entries, scanner.Err()
+}
+
+// yamlScalar strips the quotes or trailing comment from a plain scalar.
+func yamlScalar(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	if before, _, ok := strings.Cut(value, " #"); ok {
+		value = before
+	}
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	document := `# written by gh
+github.com:
+    git_protocol: https
+    user: octocat   # the active account
+    users:
+        octocat:
+            oauth_token: "gho_quoted"
+        hubot:
+            oauth_token: gho_other
+    tags:
+        - one
+"localhost:3000":
+  oauth_token: 'local'
+`
+
+	entries, err := parseYAML(strings.NewReader(document))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got := map[string]string{}
+	for _, entry := range entries {
+		got[strings.Join(entry.path, " > ")] = entry.value
+	}
+
+	expected := map[string]string{
+		"github.com":                                 "",
+		"github.com > git_protocol":                  "https",
+		"github.com > user":                          "octocat",
+		"github.com > users":                         "",
+		"github.com > users > octocat":               "",
+		"github.com > users > octocat > oauth_token": "gho_quoted",
+		"github.com > users > hubot":                 "",
+		"github.com > users > hubot > oauth_token":   "gho_other",
+		"github.com > tags":                          "",
+		"localhost:3000":                             "",
+		"localhost:3000 > oauth_token":               "local",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Unexpected entries:\n got %v\nwant %v", got, expected)
+	}
+}
+
+func TestLoadGHHosts(t *testing.T) {
+	dir := t.TempDir()
+
+	gh, err := loadGHHosts(filepath.Join(dir, "missing.yml"))
+	if err != nil || len(gh.hosts) != 0 || gh.defaultHost() != "github.com" {
+		t.Errorf("Expected a missing file to mean no hosts, got %+v, %v", gh, err)
+	}
+
+	path := writeTestFile(t, "hosts.yml", `ghe.example.com:
+    oauth_token: ghe_token
+    user: octocat
+github.com:
+    user: octocat
+    users:
+        octocat:
+            oauth_token: gho_active
+        hubot:
+            oauth_token: gho_inactive
+`)
+
+	gh, err = loadGHHosts(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gh.tokens["ghe.example.com"] != "ghe_token" {
+		t.Errorf("Expected the host token, got %q", gh.tokens["ghe.example.com"])
+	}
+	if gh.tokens["github.com"] != "gho_active" {
+		t.Errorf("Expected the active account's token, got %q", gh.tokens["github.com"])
+	}
+	if gh.defaultHost() != "github.com" {
+		t.Errorf("Expected github.com as default while logged in there, got %q", gh.defaultHost())
+	}
+
+	path = writeTestFile(t, "enterprise.yml", "ghe.example.com:\n    user: octocat\n")
+	gh, err = loadGHHosts(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gh.defaultHost() != "ghe.example.com" {
+		t.Errorf("Expected the only configured host as default, got %q", gh.defaultHost())
+	}
+	if len(gh.tokens) != 0 {
+		t.Errorf("Expected no tokens for a keyring login, got %v", gh.tokens)
+	}
+}
+
+func TestGHHostsPath(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", "")
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	if path := ghHostsPath(); path != filepath.Join("/xdg", "gh", "hosts.yml") {
+		t.Errorf("Expected hosts.yml under XDG_CONFIG_HOME, got %q", path)
+	}
+
+	t.Setenv("GH_CONFIG_DIR", "/gh")
+	if path := ghHostsPath(); path != filepath.Join("/gh", "hosts.yml") {
+		t.Errorf("Expected GH_CONFIG_DIR to win, got %q", path)
+	}
+}
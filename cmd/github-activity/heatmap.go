@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/client"
+	"github.com/dmitriy-zverev/github-activity/events"
+	"github.com/dmitriy-zverev/github-activity/filter"
+)
+
+var heatmapColors = []string{
+	"\033[38;5;238m",
+	"\033[38;5;22m",
+	"\033[38;5;28m",
+	"\033[38;5;34m",
+	"\033[38;5;40m",
+}
+
+var heatmapShades = []string{"·", "░", "▒", "▓", "█"}
+
+func runHeatmap(ctx context.Context, args []string) error {
+	username, args, ok := splitUsername(args)
+	if !ok {
+		return errUsage
+	}
+
+	opts, err := parseOptions(args)
+	if err != nil {
+		return usageError("parsing %v", err)
+	}
+
+	ctx, cancel := opts.fetchContext(ctx)
+	defer cancel()
+
+	weeks := DEFAULT_HEATMAP_WEEKS
+	if value, ok := argValue(args, "--weeks"); ok {
+		weeks, err = strconv.Atoi(value)
+		if err != nil || weeks < 1 {
+			return usageError("parsing weeks: %v is not a positive number", value)
+		}
+	}
+
+	until := time.Now().In(opts.location)
+	if value, ok := argValue(args, "--until"); ok {
+		until, err = time.ParseInLocation(DAY_FORMAT, value, opts.location)
+		if err != nil {
+			return usageError("parsing until date: %v is not a %s date", value, DAY_FORMAT)
+		}
+	}
+
+	if opts.online() {
+		fmt.Fprintf(os.Stderr, "Fetching up to %d events for '%s'...\n", client.MAX_EVENTS, opts.qualify(username))
+		fmt.Fprintln(os.Stderr, opts.credentials)
+	}
+
+	activities, err := allUserActivities(ctx, username, opts)
+	if err != nil {
+		return fmt.Errorf("fetching user activity: %w", err)
+	}
+
+	activities, err = filter.Apply(activities, opts.filters)
+	if err != nil {
+		return filterError(err)
+	}
+
+	counts := countByDay(activities, opts.location)
+	if len(counts) < 1 {
+		return exitError{code: EXIT_NO_RESULTS, err: errors.New("no dated events to draw")}
+	}
+
+	renderHeatmap(os.Stdout, counts, until, weeks, os.Getenv("NO_COLOR") == "")
+	return nil
+}
+
+// countByDay counts activities per calendar day in location.
+func countByDay(activities []events.Event, location *time.Location) map[string]int {
+	counts := map[string]int{}
+
+	for _, activity := range activities {
+		if activity.CreatedAt.IsZero() {
+			continue
+		}
+		counts[activity.CreatedAt.In(location).Format(DAY_FORMAT)]++
+	}
+
+	return counts
+}
+
+func renderHeatmap(w io.Writer, counts map[string]int, until time.Time, weeks int, color bool) {
+	until = time.Date(until.Year(), until.Month(), until.Day(), 0, 0, 0, 0, until.Location())
+	start := until.AddDate(0, 0, -int(until.Weekday())-(weeks-1)*7)
+
+	maxCount := 0
+	for day, count := range counts {
+		date, err := time.ParseInLocation(DAY_FORMAT, day, until.Location())
+		if err != nil || date.Before(start) || date.After(until) {
+			continue
+		}
+		maxCount = max(maxCount, count)
+	}
+
+	fmt.Fprintf(w, "    %s\n", heatmapMonthLabels(start, weeks))
+
+	for weekday := range 7 {
+		label := ""
+		if weekday%2 == 1 {
+			label = time.Weekday(weekday).String()[:3]
+		}
+		fmt.Fprintf(w, "%-4s", label)
+
+		for week := range weeks {
+			date := start.AddDate(0, 0, week*7+weekday)
+			if date.After(until) {
+				break
+			}
+
+			level := heatmapLevel(counts[date.Format(DAY_FORMAT)], maxCount)
+			fmt.Fprintf(w, "%s ", heatmapCell(level, color))
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "\n    Less ")
+	for level := range heatmapShades {
+		fmt.Fprintf(w, "%s ", heatmapCell(level, color))
+	}
+	fmt.Fprintf(w, "More (max %d events/day)\n", maxCount)
+}
+
+func heatmapMonthLabels(start time.Time, weeks int) string {
+	labels := []rune(strings.Repeat(" ", weeks*2))
+
+	month := time.Month(0)
+	next := 0
+	for week := range weeks {
+		date := start.AddDate(0, 0, week*7)
+		if date.Month() == month {
+			continue
+		}
+		month = date.Month()
+
+		name := []rune(month.String()[:3])
+		if week*2 >= next && week*2+len(name) <= len(labels) {
+			copy(labels[week*2:], name)
+			next = week*2 + len(name) + 1
+		}
+	}
+
+	return strings.TrimRight(string(labels), " ")
+}
+
+func heatmapLevel(count, maxCount int) int {
+	if count < 1 || maxCount < 1 {
+		return 0
+	}
+
+	levels := len(heatmapShades) - 1
+	return (count*levels + maxCount - 1) / maxCount
+}
+
+func heatmapCell(level int, color bool) string {
+	if !color {
+		return heatmapShades[level]
+	}
+	return heatmapColors[level] + HEATMAP_BLOCK + COLOR_RESET
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+func TestRenderHeatmap(t *testing.T) {
+	counts := map[string]int{
+		"2025-02-24": 1,
+		"2025-03-03": 4,
+		"2025-03-08": 2,
+		"2025-01-01": 50, // outside the range, must not affect shading
+	}
+	until := time.Date(2025, 3, 8, 18, 0, 0, 0, time.Local)
+
+	var buf bytes.Buffer
+	renderHeatmap(&buf, counts, until, 2, false)
+
+	expected := strings.Join([]string{
+		"    Feb",
+		"    · · ",
+		"Mon ░ █ ",
+		"    · · ",
+		"Wed · · ",
+		"    · · ",
+		"Fri · · ",
+		"    · ▒ ",
+		"",
+		"    Less · ░ ▒ ▓ █ More (max 4 events/day)",
+		"",
+	}, "\n")
+
+	if buf.String() != expected {
+		t.Errorf("Expected heatmap:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestRenderHeatmapStopsAtUntil(t *testing.T) {
+	until := time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local) // a Tuesday
+
+	var buf bytes.Buffer
+	renderHeatmap(&buf, map[string]int{"2025-03-04": 1}, until, 1, false)
+
+	lines := strings.Split(buf.String(), "\n")
+	if lines[3] != "    █ " {
+		t.Errorf("Expected Tuesday cell to be drawn, got %q", lines[3])
+	}
+	if lines[4] != "Wed " {
+		t.Errorf("Expected no cells after the until date, got %q", lines[4])
+	}
+}
+
+func TestHeatmapLevel(t *testing.T) {
+	tests := []struct {
+		count    int
+		maxCount int
+		expected int
+	}{
+		{0, 10, 0},
+		{1, 10, 1},
+		{5, 10, 2},
+		{8, 10, 4},
+		{10, 10, 4},
+		{3, 0, 0},
+	}
+
+	for _, tt := range tests {
+		if level := heatmapLevel(tt.count, tt.maxCount); level != tt.expected {
+			t.Errorf("heatmapLevel(%d, %d) = %d, want %d", tt.count, tt.maxCount, level, tt.expected)
+		}
+	}
+}
+
+func TestCountByDay(t *testing.T) {
+	activities := []events.Event{
+		eventFromJSON(t, `{"type":"PushEvent","created_at":"2025-03-02T11:30:00Z"}`),
+		eventFromJSON(t, `{"type":"PushEvent","created_at":"2025-03-02T12:30:00Z"}`),
+		eventFromJSON(t, `{"type":"PushEvent"}`),
+	}
+
+	counts := countByDay(activities, time.UTC)
+	if len(counts) != 1 || counts["2025-03-02"] != 2 {
+		t.Errorf("Expected 2 events on 2025-03-02, got %v", counts)
+	}
+
+	// 12:30 UTC, unlike 11:30, is already the next day in UTC+12
+	counts = countByDay(activities, time.FixedZone("UTC+12", 12*3600))
+	if counts["2025-03-02"] != 1 || counts["2025-03-03"] != 1 {
+		t.Errorf("Expected the events split across the day boundary in UTC+12, got %v", counts)
+	}
+}
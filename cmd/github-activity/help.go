@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+func help(w io.Writer) {
+	fmt.Fprintln(w, "Usage: github-activity <username>")
+	fmt.Fprintln(w, "       github-activity --input <events.json|->")
+	fmt.Fprintln(w, "       github-activity --org <org> --offline")
+	fmt.Fprintln(w, "       github-activity stats <username>")
+	fmt.Fprintln(w, "       github-activity heatmap <username> [--weeks n] [--until yyyy-mm-dd] [--tz timezone]")
+	fmt.Fprintln(w, "       github-activity histogram <username> [--tz timezone]")
+	fmt.Fprintln(w, "       github-activity streaks <username> [--gap-days n] [--tz timezone]")
+	fmt.Fprintln(w, "       github-activity compare <username> <username>...")
+	fmt.Fprintln(w, "       github-activity team <roster.json|team name>")
+	fmt.Fprintln(w, "       github-activity sync <username>... [--team roster.json|team name] [--org org,org]")
+	fmt.Fprintln(w, "       github-activity fake-server --fixtures <dir> [--addr host:port] [--rate-limit n] [--fail-every n] [--fail-status code]")
+	fmt.Fprintln(w, "       github-activity config [--profile name] (print the effective configuration)")
+	fmt.Fprintln(w, "       github-activity completion <bash|zsh|fish> (print a shell completion script)")
+	fmt.Fprintln(w, "\nAdditional parameters:")
+	fmt.Fprintln(w, "  --config [file] (configuration file, defaults to ~/.config/github-activity/config.json)")
+	fmt.Fprintln(w, "  --profile [name] (apply a named profile from the configuration file)")
+	fmt.Fprintln(w, "  -f (--filter) [event type]")
+	fmt.Fprintln(w, "  -p (--page) [page number]")
+	fmt.Fprintln(w, "  -n (--number) [per page events]")
+	fmt.Fprintln(w, "  -o (--output) [text|json]")
+	fmt.Fprintln(w, "  --group-by [repo|day|type]")
+	fmt.Fprintln(w, "  -v (--verbose) (list commits under pushes)")
+	fmt.Fprintln(w, "  --no-collapse (list similar consecutive events separately)")
+	fmt.Fprintln(w, "  --interactive (browse events in a full-screen list: / filters, o opens, q quits)")
+	fmt.Fprintln(w, "  --offline (read events from the local archive instead of the API)")
+	fmt.Fprintln(w, "  --archive-dir [path] (location of the local archive)")
+	fmt.Fprintln(w, "  --org [org] (with --offline and no username, read an organization's archive)")
+	fmt.Fprintln(w, "  --input [file|-] (read saved events from a file or stdin)")
+	fmt.Fprintln(w, "  --record [dir] (save every HTTP exchange as a fixture)")
+	fmt.Fprintln(w, "  --replay [dir] (answer HTTP requests from recorded fixtures)")
+	fmt.Fprintln(w, "  --host [hostname] (GitHub Enterprise Server host, defaults to $GH_HOST or github.com)")
+	fmt.Fprintln(w, "  --ca-bundle [file] (extra PEM certificates to trust, e.g. a corporate CA)")
+	fmt.Fprintln(w, "  --api-url [url] (GitHub API base URL, e.g. a fake-server, requests to it are unauthenticated)")
+	fmt.Fprintln(w, "  --api-url-token (send the token for --host to --api-url as well)")
+	fmt.Fprintln(w, "  --timeout [duration] (give up on the API after e.g. 30s, 0 waits forever)")
+	fmt.Fprintln(w, "  --max-attempts [n] (tries per API request on network errors, 5xx and secondary rate limits, 1 disables retries)")
+	fmt.Fprintln(w, "  --retry-delay [duration] (wait before the first retry, doubled with jitter for each further one)")
+	fmt.Fprintln(w, "  --grep [pattern] (search commit messages, titles and comments)")
+	fmt.Fprintln(w, "  --regex (treat --grep pattern as a regular expression)")
+	fmt.Fprintln(w, "  --ignore-case (case-insensitive --grep)")
+	fmt.Fprintln(w, "  --no-bots (hide events from bots and automation)")
+	fmt.Fprintln(w, "  --only-bots (show only events from bots and automation)")
+	fmt.Fprintln(w, "  --all-bots (show events from bots and people alike, overriding the config file)")
+	fmt.Fprintln(w, "  --bot-denylist [login,login] (extra logins treated as bots)")
+	fmt.Fprintln(w, "\nEvery option can also be set through a GITHUB_ACTIVITY_* variable, e.g.")
+	fmt.Fprintln(w, "GITHUB_ACTIVITY_PER_PAGE=50 or GITHUB_ACTIVITY_NO_BOTS=true. Flags win over")
+	fmt.Fprintln(w, "the environment, which wins over the configuration file.")
+	fmt.Fprintln(w, "\nErrors and progress messages go to stderr. Exit codes: 0 success, 1 error,")
+	fmt.Fprintln(w, "2 usage, 3 not found, 4 auth, 5 rate limit, 6 network, 7 no results after filter.")
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/client"
+	"github.com/dmitriy-zverev/github-activity/events"
+	"github.com/dmitriy-zverev/github-activity/filter"
+	"github.com/dmitriy-zverev/github-activity/render"
+)
+
+type activityHistogram struct {
+	Timezone  string            `json:"timezone"`
+	ByHour    []histogramBucket `json:"by_hour"`
+	ByWeekday []histogramBucket `json:"by_weekday"`
+}
+
+type histogramBucket struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+func runHistogram(ctx context.Context, args []string) error {
+	username, args, ok := splitUsername(args)
+	if !ok {
+		return errUsage
+	}
+
+	opts, err := parseOptions(args)
+	if err != nil {
+		return usageError("parsing %v", err)
+	}
+
+	ctx, cancel := opts.fetchContext(ctx)
+	defer cancel()
+
+	if opts.output == render.OUTPUT_TEXT && opts.online() {
+		fmt.Fprintf(os.Stderr, "Fetching up to %d events for '%s'...\n", client.MAX_EVENTS, opts.qualify(username))
+		fmt.Fprintln(os.Stderr, opts.credentials)
+	}
+
+	activities, err := allUserActivities(ctx, username, opts)
+	if err != nil {
+		return fmt.Errorf("fetching user activity: %w", err)
+	}
+
+	activities, err = filter.Apply(activities, opts.filters)
+	if err != nil {
+		return filterError(err)
+	}
+
+	histogram := computeHistogram(activities, opts.location)
+
+	if opts.output == render.OUTPUT_JSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(histogram); err != nil {
+			return fmt.Errorf("printing user histogram: %w", err)
+		}
+		return nil
+	}
+
+	printHistogram(os.Stdout, histogram)
+	return nil
+}
+
+func computeHistogram(activities []events.Event, location *time.Location) activityHistogram {
+	histogram := activityHistogram{
+		Timezone:  location.String(),
+		ByHour:    make([]histogramBucket, 24),
+		ByWeekday: make([]histogramBucket, 7),
+	}
+
+	for hour := range histogram.ByHour {
+		histogram.ByHour[hour].Label = fmt.Sprintf("%02d:00", hour)
+	}
+	for day := range histogram.ByWeekday {
+		histogram.ByWeekday[day].Label = mondayFirstWeekday(day).String()
+	}
+
+	for _, activity := range activities {
+		if activity.CreatedAt.IsZero() {
+			continue
+		}
+
+		createdAt := activity.CreatedAt.In(location)
+		histogram.ByHour[createdAt.Hour()].Count++
+		histogram.ByWeekday[(int(createdAt.Weekday())+6)%7].Count++
+	}
+
+	return histogram
+}
+
+func mondayFirstWeekday(day int) time.Weekday {
+	return time.Weekday((day + 1) % 7)
+}
+
+func printHistogram(w io.Writer, histogram activityHistogram) {
+	fmt.Fprintf(w, "Events by hour of day (%s)\n", histogram.Timezone)
+	printBars(w, histogram.ByHour)
+
+	fmt.Fprintf(w, "\nEvents by weekday (%s)\n", histogram.Timezone)
+	printBars(w, histogram.ByWeekday)
+}
+
+func printBars(w io.Writer, buckets []histogramBucket) {
+	maxCount := 0
+	for _, bucket := range buckets {
+		maxCount = max(maxCount, bucket.Count)
+	}
+
+	for _, bucket := range buckets {
+		width := 0
+		if maxCount > 0 {
+			width = (bucket.Count*HISTOGRAM_BAR_WIDTH + maxCount - 1) / maxCount
+		}
+
+		fmt.Fprintf(
+			w,
+			"  %-9s |%-*s %d\n",
+			bucket.Label,
+			HISTOGRAM_BAR_WIDTH,
+			strings.Repeat("#", width),
+			bucket.Count,
+		)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+func TestComputeHistogram(t *testing.T) {
+	activities := []events.Event{
+		// Monday 2025-03-03 23:30 UTC is Tuesday 08:30 in Tokyo
+		eventFromJSON(t, `{"type":"PushEvent","created_at":"2025-03-03T23:30:00Z"}`),
+		eventFromJSON(t, `{"type":"PushEvent","created_at":"2025-03-04T00:10:00Z"}`),
+		eventFromJSON(t, `{"type":"PushEvent","created_at":"2025-03-09T12:00:00Z"}`),
+		eventFromJSON(t, `{"type":"PushEvent"}`),
+	}
+
+	histogram := computeHistogram(activities, time.UTC)
+	if histogram.Timezone != "UTC" {
+		t.Errorf("Expected UTC timezone, got %s", histogram.Timezone)
+	}
+	if histogram.ByHour[23].Count != 1 || histogram.ByHour[0].Count != 1 || histogram.ByHour[12].Count != 1 {
+		t.Errorf("Unexpected hour counts: %v", histogram.ByHour)
+	}
+	if histogram.ByWeekday[0].Label != "Monday" || histogram.ByWeekday[0].Count != 1 {
+		t.Errorf("Unexpected Monday bucket: %+v", histogram.ByWeekday[0])
+	}
+	if histogram.ByWeekday[6].Label != "Sunday" || histogram.ByWeekday[6].Count != 1 {
+		t.Errorf("Unexpected Sunday bucket: %+v", histogram.ByWeekday[6])
+	}
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("Timezone database not available: %v", err)
+	}
+
+	histogram = computeHistogram(activities, tokyo)
+	if histogram.ByHour[8].Count != 1 || histogram.ByHour[9].Count != 1 {
+		t.Errorf("Unexpected Tokyo hour counts: %v", histogram.ByHour)
+	}
+	if histogram.ByWeekday[1].Count != 2 {
+		t.Errorf("Expected 2 Tuesday events in Tokyo, got %d", histogram.ByWeekday[1].Count)
+	}
+}
+
+func TestPrintBars(t *testing.T) {
+	var buf bytes.Buffer
+	printBars(&buf, []histogramBucket{
+		{Label: "Monday", Count: 4},
+		{Label: "Tuesday", Count: 1},
+		{Label: "Wednesday", Count: 0},
+	})
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 bars, got %d", len(lines))
+	}
+
+	expected := "  Monday    |" + strings.Repeat("#", HISTOGRAM_BAR_WIDTH) + " 4"
+	if lines[0] != expected {
+		t.Errorf("Expected %q, got %q", expected, lines[0])
+	}
+	if strings.Count(lines[1], "#") != HISTOGRAM_BAR_WIDTH/4 {
+		t.Errorf("Expected a quarter-width bar, got %q", lines[1])
+	}
+	if strings.Contains(lines[2], "#") {
+		t.Errorf("Expected an empty bar, got %q", lines[2])
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+// readEventsInput reads saved events from a file, or from stdin when path
+// is "-".
+func readEventsInput(path string, stdin io.Reader) ([]events.Event, error) {
+	if path == STDIN_INPUT {
+		return events.Decode(stdin)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return []events.Event{}, err
+	}
+	defer file.Close()
+
+	activities, err := events.Decode(file)
+	if err != nil {
+		return []events.Event{}, fmt.Errorf("couldn't decode %s: %v", path, err)
+	}
+
+	return activities, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+const testEventsArray = `[
+	{"id":"2","type":"PushEvent","actor":{"login":"octocat"},"repo":{"name":"a/b"},"payload":{"commits":[{"message":"Fix JIRA-1"}]}},
+	{"id":"1","type":"WatchEvent","actor":{"login":"octocat"},"repo":{"name":"a/c"},"payload":{"action":"started"}}
+]`
+
+const testEventsNDJSON = `{"id":"2","type":"PushEvent","repo":{"name":"a/b"},"payload":{"commits":[{"message":"Fix JIRA-1"}]}}
+{"id":"1","type":"WatchEvent","repo":{"name":"a/c"},"payload":{"action":"started"}}
+`
+
+func TestReadEventsInput(t *testing.T) {
+	activities, err := readEventsInput(STDIN_INPUT, strings.NewReader(testEventsNDJSON))
+	if err != nil || len(activities) != 2 || activities[0].Type != events.PUSH_EVENT {
+		t.Errorf("Unexpected stdin events: %v, %v", activities, err)
+	}
+
+	activities, err = readEventsInput(writeTestFile(t, "events.json", testEventsArray), nil)
+	if err != nil || len(activities) != 2 || activities[1].Type != events.WATCH_EVENT {
+		t.Errorf("Unexpected file events: %v, %v", activities, err)
+	}
+
+	if _, err := readEventsInput(writeTestFile(t, "broken.json", "[{"), nil); err == nil {
+		t.Error("Expected error for broken file")
+	}
+	if _, err := readEventsInput("/nonexistent/events.json", nil); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestRunActivityWithInput(t *testing.T) {
+	path := writeTestFile(t, "events.json", testEventsArray)
+
+	tests := []struct {
+		name          string
+		args          []string
+		expected      string
+		expectedError string
+		expectedCode  int
+	}{
+		{
+			name:     "All events",
+			args:     []string{"--input", path},
+			expected: "  - Pushed 1 commits to a/b\n  - Started watching a/c\n",
+		},
+		{
+			name:     "Username with filter",
+			args:     []string{"octocat", "--input", path, "-f", "watch"},
+			expected: "  - Started watching a/c\n",
+		},
+		{
+			name:          "No result after filter",
+			args:          []string{"--input", path, "-f", "Release"},
+			expectedError: "no result for 'Release' filter",
+			expectedCode:  EXIT_NO_RESULTS,
+		},
+		{
+			name:     "Grep in JSON",
+			args:     []string{"--input", path, "--grep", "JIRA", "-o", "json"},
+			expected: "\"summary\": \"Pushed 1 commits to a/b\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := captureStdout(func() error {
+				return runActivity(context.Background(), tt.args)
+			})
+
+			if tt.expectedError != "" {
+				if err == nil || err.Error() != tt.expectedError || exitCode(err) != tt.expectedCode {
+					t.Errorf("Expected error %q with exit code %d, got %v", tt.expectedError, tt.expectedCode, err)
+				}
+				if output != "" {
+					t.Errorf("Expected nothing on stdout, got %q", output)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !strings.Contains(output, tt.expected) {
+				t.Errorf("Expected output to contain %q, got %q", tt.expected, output)
+			}
+		})
+	}
+}
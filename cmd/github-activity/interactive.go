@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+	"github.com/dmitriy-zverev/github-activity/tui"
+)
+
+// runInteractive shows a user's events in the full-screen browser. Each
+// further page gets its own --timeout, since the session lasts as long as the
+// user keeps browsing.
+func runInteractive(ctx context.Context, username string, opts cliOptions) error {
+	fetch := func(ctx context.Context, page int) ([]events.Event, error) {
+		ctx, cancel := opts.fetchContext(ctx)
+		defer cancel()
+
+		pageOpts := opts
+		pageOpts.page = strconv.Itoa(page)
+		return userActivities(ctx, username, pageOpts)
+	}
+
+	page, _ := strconv.Atoi(opts.page)
+	if opts.online() {
+		fmt.Fprintf(
+			os.Stderr,
+			"Fetching activity for '%s' at page %s with %s per page events...\n",
+			opts.qualify(username),
+			opts.page,
+			opts.perPage,
+		)
+		fmt.Fprintln(os.Stderr, opts.credentials)
+	}
+
+	activities, err := fetch(ctx, page)
+	if err != nil {
+		return fmt.Errorf("fetching user activity: %w", err)
+	}
+
+	title := opts.qualify(username)
+	switch {
+	case opts.input != "":
+		title = opts.input
+	case username == "":
+		title = opts.qualify(opts.org)
+	}
+
+	browserOpts := tui.Options{
+		Title:    title,
+		Host:     opts.host,
+		Filters:  opts.filters,
+		Collapse: opts.collapse,
+		Location: opts.location,
+		Page:     page,
+	}
+	// An input file is read whole, so there are no further pages
+	if opts.input == "" {
+		browserOpts.Fetch = fetch
+	}
+
+	if err := tui.Run(ctx, activities, browserOpts); err != nil {
+		return fmt.Errorf("running interactive mode: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+
+	"github.com/dmitriy-zverev/github-activity/filter"
+	"github.com/dmitriy-zverev/github-activity/render"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+// run executes the command in args and returns the exit status. Errors and
+// progress go to stderr so stdout only ever holds results.
+func run(args []string) int {
+	if len(args) > 0 && slices.Contains(HELP_COMMANDS, args[0]) {
+		help(os.Stdout)
+		return EXIT_OK
+	}
+
+	// Variables may stand in for arguments, such as GITHUB_ACTIVITY_INPUT
+	// for the username, so they are applied before anything is checked
+	args, err := withEnvOptions(args, os.Getenv)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: parsing environment: %v\n", err)
+		return EXIT_USAGE
+	}
+	if len(args) < 1 {
+		help(os.Stderr)
+		return EXIT_USAGE
+	}
+
+	// The first Ctrl-C cancels in-flight requests, a second one kills the
+	// process as usual
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	switch args[0] {
+	case STATS_COMMAND:
+		err = runStats(ctx, args[1:])
+	case HEATMAP_COMMAND:
+		err = runHeatmap(ctx, args[1:])
+	case HISTOGRAM_COMMAND:
+		err = runHistogram(ctx, args[1:])
+	case STREAKS_COMMAND:
+		err = runStreaks(ctx, args[1:])
+	case COMPARE_COMMAND:
+		err = runCompare(ctx, args[1:])
+	case TEAM_COMMAND:
+		err = runTeam(ctx, args[1:])
+	case SYNC_COMMAND:
+		err = runSync(ctx, args[1:])
+	case FAKE_SERVER_COMMAND:
+		err = runFakeServer(ctx, args[1:])
+	case CONFIG_COMMAND:
+		err = runConfig(ctx, args[1:])
+	case COMPLETION_COMMAND:
+		err = runCompletion(ctx, args[1:])
+	default:
+		err = runActivity(ctx, args)
+	}
+
+	switch {
+	case err == nil:
+		return EXIT_OK
+	case errors.Is(err, errUsage):
+		help(os.Stderr)
+		return EXIT_USAGE
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	if ctx.Err() != nil {
+		return EXIT_INTERRUPTED
+	}
+	return exitCode(err)
+}
+
+func runActivity(ctx context.Context, args []string) error {
+	username, args, ok := splitUsername(args)
+	if !ok {
+		return errUsage
+	}
+
+	opts, err := parseOptions(args)
+	if err != nil {
+		return usageError("parsing %v", err)
+	}
+
+	if opts.interactive {
+		return runInteractive(ctx, username, opts)
+	}
+
+	ctx, cancel := opts.fetchContext(ctx)
+	defer cancel()
+
+	if opts.output == render.OUTPUT_TEXT && opts.online() {
+		fmt.Fprintf(
+			os.Stderr,
+			"Fetching activity for '%s' at page %s with %s per page events...\n",
+			opts.qualify(username),
+			opts.page,
+			opts.perPage,
+		)
+		fmt.Fprintln(os.Stderr, opts.credentials)
+	}
+
+	activities, err := userActivities(ctx, username, opts)
+	if err != nil {
+		return fmt.Errorf("fetching user activity: %w", err)
+	}
+
+	activities, err = filter.Apply(activities, opts.filters)
+	if err != nil {
+		return filterError(err)
+	}
+
+	if err := render.Print(os.Stdout, activities, opts.renderOptions()); err != nil {
+		return fmt.Errorf("printing user activity: %w", err)
+	}
+	return nil
+}
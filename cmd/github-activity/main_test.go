@@ -1,10 +1,59 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"os"
 	"testing"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+	"github.com/dmitriy-zverev/github-activity/filter"
 )
 
+// TestMain keeps the developer's own config files and host out of the tests.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "github-activity-test")
+	if err != nil {
+		panic(err)
+	}
+
+	os.Setenv("XDG_CONFIG_HOME", dir)
+	os.Setenv("GH_CONFIG_DIR", dir)
+	os.Unsetenv("GH_HOST")
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func eventFromJSON(t *testing.T, data string) events.Event {
+	t.Helper()
+
+	var event events.Event
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		t.Fatalf("Couldn't decode test event: %v", err)
+	}
+
+	return event
+}
+
+func captureStdout(fn func() error) (string, error) {
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := fn()
+
+	w.Close()
+	os.Stdout = old
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+
+	return buf.String(), err
+}
+
 func TestMainFunctionArguments(t *testing.T) {
 	// Save original args
 	originalArgs := os.Args
@@ -205,81 +254,65 @@ func TestConstants(t *testing.T) {
 	}
 }
 
-func TestEventTypeConstants(t *testing.T) {
-	// Test that event type constants are defined correctly
-	expectedConstants := map[string]string{
-		"PUSH_EVENT":           "PushEvent",
-		"PULL_REQUEST_EVENT":   "PullRequestEvent",
-		"CREATE_EVENT":         "CreateEvent",
-		"WATCH_EVENT":          "WatchEvent",
-		"DELETE_EVENT":         "DeleteEvent",
-		"FORK_EVENT":           "ForkEvent",
-		"ISSUES_EVENT":         "IssuesEvent",
-		"ISSUES_COMMENT_EVENT": "IssueCommentEvent",
-		"PUBLIC_EVENT":         "PublicEvent",
-		"MEMBER_EVENT":         "MemberEvent",
-		"RELEASE_EVENT":        "ReleaseEvent",
-	}
-
-	actualConstants := map[string]string{
-		"PUSH_EVENT":           PUSH_EVENT,
-		"PULL_REQUEST_EVENT":   PULL_REQUEST_EVENT,
-		"CREATE_EVENT":         CREATE_EVENT,
-		"WATCH_EVENT":          WATCH_EVENT,
-		"DELETE_EVENT":         DELETE_EVENT,
-		"FORK_EVENT":           FORK_EVENT,
-		"ISSUES_EVENT":         ISSUES_EVENT,
-		"ISSUES_COMMENT_EVENT": ISSUES_COMMENT_EVENT,
-		"PUBLIC_EVENT":         PUBLIC_EVENT,
-		"MEMBER_EVENT":         MEMBER_EVENT,
-		"RELEASE_EVENT":        RELEASE_EVENT,
-	}
-
-	for name, expected := range expectedConstants {
-		if actual, exists := actualConstants[name]; !exists {
-			t.Errorf("Constant %s is not defined", name)
-		} else if actual != expected {
-			t.Errorf("Expected %s to be %s, got %s", name, expected, actual)
-		}
-	}
-}
-
 // Integration test helper functions
-func createTestGithubUserData() []githubUserData {
-	return []githubUserData{
+func createTestGithubUserData() []events.Event {
+	return []events.Event{
 		{
-			Type: PUSH_EVENT,
+			Type: events.PUSH_EVENT,
 			Repo: struct {
 				Name string `json:"name"`
 			}{Name: "test-repo"},
 			Payload: struct {
 				Ref     string `json:"ref"`
 				RefType string `json:"ref_type"`
+				Size    int    `json:"size"`
+				Before  string `json:"before"`
+				Head    string `json:"head"`
 				Commits []struct {
+					Sha     string `json:"sha"`
 					Message string `json:"message"`
+					Author  struct {
+						Name string `json:"name"`
+					} `json:"author"`
 				} `json:"commits"`
 				Action string `json:"action"`
+				Number int    `json:"number"`
 				Forkee struct {
 					FullName string `json:"full_name"`
+					HTMLURL  string `json:"html_url"`
 					Owner    struct {
 						Login string `json:"login"`
 					} `json:"owner"`
 				} `json:"forkee"`
 				Issue struct {
-					Title string `json:"title"`
+					Number  int    `json:"number"`
+					Title   string `json:"title"`
+					HTMLURL string `json:"html_url"`
 				} `json:"issue"`
 				PullReq struct {
-					Title string `json:"title"`
+					Title   string `json:"title"`
+					Merged  bool   `json:"merged"`
+					HTMLURL string `json:"html_url"`
 				} `json:"pull_request"`
 				Member struct {
 					Login string `json:"login"`
 				} `json:"member"`
 				Release struct {
-					Name string `json:"name"`
+					Name    string `json:"name"`
+					TagName string `json:"tag_name"`
+					HTMLURL string `json:"html_url"`
 				} `json:"release"`
+				Comment struct {
+					Body    string `json:"body"`
+					HTMLURL string `json:"html_url"`
+				} `json:"comment"`
 			}{
 				Commits: []struct {
+					Sha     string `json:"sha"`
 					Message string `json:"message"`
+					Author  struct {
+						Name string `json:"name"`
+					} `json:"author"`
 				}{
 					{Message: "Initial commit"},
 					{Message: "Add feature"},
@@ -287,69 +320,107 @@ func createTestGithubUserData() []githubUserData {
 			},
 		},
 		{
-			Type: WATCH_EVENT,
+			Type: events.WATCH_EVENT,
 			Repo: struct {
 				Name string `json:"name"`
 			}{Name: "another-repo"},
 			Payload: struct {
 				Ref     string `json:"ref"`
 				RefType string `json:"ref_type"`
+				Size    int    `json:"size"`
+				Before  string `json:"before"`
+				Head    string `json:"head"`
 				Commits []struct {
+					Sha     string `json:"sha"`
 					Message string `json:"message"`
+					Author  struct {
+						Name string `json:"name"`
+					} `json:"author"`
 				} `json:"commits"`
 				Action string `json:"action"`
+				Number int    `json:"number"`
 				Forkee struct {
 					FullName string `json:"full_name"`
+					HTMLURL  string `json:"html_url"`
 					Owner    struct {
 						Login string `json:"login"`
 					} `json:"owner"`
 				} `json:"forkee"`
 				Issue struct {
-					Title string `json:"title"`
+					Number  int    `json:"number"`
+					Title   string `json:"title"`
+					HTMLURL string `json:"html_url"`
 				} `json:"issue"`
 				PullReq struct {
-					Title string `json:"title"`
+					Title   string `json:"title"`
+					Merged  bool   `json:"merged"`
+					HTMLURL string `json:"html_url"`
 				} `json:"pull_request"`
 				Member struct {
 					Login string `json:"login"`
 				} `json:"member"`
 				Release struct {
-					Name string `json:"name"`
+					Name    string `json:"name"`
+					TagName string `json:"tag_name"`
+					HTMLURL string `json:"html_url"`
 				} `json:"release"`
+				Comment struct {
+					Body    string `json:"body"`
+					HTMLURL string `json:"html_url"`
+				} `json:"comment"`
 			}{
 				Action: "started",
 			},
 		},
 		{
-			Type: CREATE_EVENT,
+			Type: events.CREATE_EVENT,
 			Repo: struct {
 				Name string `json:"name"`
 			}{Name: "new-repo"},
 			Payload: struct {
 				Ref     string `json:"ref"`
 				RefType string `json:"ref_type"`
+				Size    int    `json:"size"`
+				Before  string `json:"before"`
+				Head    string `json:"head"`
 				Commits []struct {
+					Sha     string `json:"sha"`
 					Message string `json:"message"`
+					Author  struct {
+						Name string `json:"name"`
+					} `json:"author"`
 				} `json:"commits"`
 				Action string `json:"action"`
+				Number int    `json:"number"`
 				Forkee struct {
 					FullName string `json:"full_name"`
+					HTMLURL  string `json:"html_url"`
 					Owner    struct {
 						Login string `json:"login"`
 					} `json:"owner"`
 				} `json:"forkee"`
 				Issue struct {
-					Title string `json:"title"`
+					Number  int    `json:"number"`
+					Title   string `json:"title"`
+					HTMLURL string `json:"html_url"`
 				} `json:"issue"`
 				PullReq struct {
-					Title string `json:"title"`
+					Title   string `json:"title"`
+					Merged  bool   `json:"merged"`
+					HTMLURL string `json:"html_url"`
 				} `json:"pull_request"`
 				Member struct {
 					Login string `json:"login"`
 				} `json:"member"`
 				Release struct {
-					Name string `json:"name"`
+					Name    string `json:"name"`
+					TagName string `json:"tag_name"`
+					HTMLURL string `json:"html_url"`
 				} `json:"release"`
+				Comment struct {
+					Body    string `json:"body"`
+					HTMLURL string `json:"html_url"`
+				} `json:"comment"`
 			}{
 				RefType: "repository",
 			},
@@ -362,24 +433,24 @@ func TestIntegrationFilterAndPrint(t *testing.T) {
 	testData := createTestGithubUserData()
 
 	// Test filtering and printing PushEvents (using lowercase for case-insensitive match)
-	filtered := filterEvents(testData, "pushevent")
+	filtered := filter.ByType(testData, "pushevent")
 	if len(filtered) != 1 {
 		t.Errorf("Expected 1 PushEvent, got %d", len(filtered))
 	}
 
 	// Test that the filtered event is correct (only if we have results)
-	if len(filtered) > 0 && filtered[0].Type != PUSH_EVENT {
+	if len(filtered) > 0 && filtered[0].Type != events.PUSH_EVENT {
 		t.Errorf("Expected filtered event to be PushEvent, got %s", filtered[0].Type)
 	}
 
 	// Test filtering with no matches
-	noMatches := filterEvents(testData, "NonExistentEvent")
+	noMatches := filter.ByType(testData, "NonExistentEvent")
 	if len(noMatches) != 0 {
 		t.Errorf("Expected 0 events for non-existent filter, got %d", len(noMatches))
 	}
 
 	// Test filtering with no filter (should return all)
-	allEvents := filterEvents(testData, DEFAULT_FILTER_TYPE)
+	allEvents := filter.ByType(testData, DEFAULT_FILTER_TYPE)
 	if len(allEvents) != len(testData) {
 		t.Errorf("Expected %d events with no filter, got %d", len(testData), len(allEvents))
 	}
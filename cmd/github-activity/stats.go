@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/client"
+	"github.com/dmitriy-zverev/github-activity/events"
+	"github.com/dmitriy-zverev/github-activity/filter"
+	"github.com/dmitriy-zverev/github-activity/render"
+)
+
+type activityStats struct {
+	TotalEvents        int            `json:"total_events"`
+	ByType             map[string]int `json:"by_type"`
+	ByRepo             map[string]int `json:"by_repo"`
+	CommitsPushed      int            `json:"commits_pushed"`
+	PullRequestsOpened int            `json:"pull_requests_opened"`
+	PullRequestsMerged int            `json:"pull_requests_merged"`
+	IssuesOpened       int            `json:"issues_opened"`
+	IssuesClosed       int            `json:"issues_closed"`
+	MostActiveRepo     string         `json:"most_active_repo"`
+	MostActiveDay      string         `json:"most_active_day"`
+}
+
+type statCount struct {
+	key   string
+	count int
+}
+
+func runStats(ctx context.Context, args []string) error {
+	username, args, ok := splitUsername(args)
+	if !ok {
+		return errUsage
+	}
+
+	opts, err := parseOptions(args)
+	if err != nil {
+		return usageError("parsing %v", err)
+	}
+
+	ctx, cancel := opts.fetchContext(ctx)
+	defer cancel()
+
+	if opts.output == render.OUTPUT_TEXT && opts.online() {
+		fmt.Fprintf(os.Stderr, "Fetching up to %d events for '%s'...\n", client.MAX_EVENTS, opts.qualify(username))
+		fmt.Fprintln(os.Stderr, opts.credentials)
+	}
+
+	activities, err := allUserActivities(ctx, username, opts)
+	if err != nil {
+		return fmt.Errorf("fetching user activity: %w", err)
+	}
+
+	activities, err = filter.Apply(activities, opts.filters)
+	if err != nil {
+		return filterError(err)
+	}
+
+	if err := printStats(computeStats(activities, opts.location), opts.output); err != nil {
+		return fmt.Errorf("printing user stats: %w", err)
+	}
+	return nil
+}
+
+// computeStats summarizes activities, with the most active day taken from
+// calendar days in location.
+func computeStats(activities []events.Event, location *time.Location) activityStats {
+	stats := activityStats{
+		TotalEvents: len(activities),
+		ByType:      map[string]int{},
+		ByRepo:      map[string]int{},
+	}
+	byDay := map[string]int{}
+
+	for _, activity := range activities {
+		stats.ByType[activity.Type]++
+		stats.ByRepo[activity.Repo.Name]++
+		if !activity.CreatedAt.IsZero() {
+			byDay[activity.CreatedAt.In(location).Format(DAY_FORMAT)]++
+		}
+
+		switch activity.Type {
+		case events.PUSH_EVENT:
+			stats.CommitsPushed += events.PushCommitCount(activity)
+		case events.PULL_REQUEST_EVENT:
+			if activity.Payload.Action == "opened" {
+				stats.PullRequestsOpened++
+			}
+			if activity.Payload.Action == "closed" && activity.Payload.PullReq.Merged {
+				stats.PullRequestsMerged++
+			}
+		case events.ISSUES_EVENT:
+			if activity.Payload.Action == "opened" {
+				stats.IssuesOpened++
+			}
+			if activity.Payload.Action == "closed" {
+				stats.IssuesClosed++
+			}
+		}
+	}
+
+	if counts := sortedCounts(stats.ByRepo); len(counts) > 0 {
+		stats.MostActiveRepo = counts[0].key
+	}
+	if counts := sortedCounts(byDay); len(counts) > 0 {
+		stats.MostActiveDay = counts[0].key
+	}
+
+	return stats
+}
+
+func sortedCounts(counts map[string]int) []statCount {
+	var sorted []statCount
+	for key, count := range counts {
+		sorted = append(sorted, statCount{key: key, count: count})
+	}
+
+	slices.SortFunc(sorted, func(a, b statCount) int {
+		if a.count != b.count {
+			return b.count - a.count
+		}
+		return strings.Compare(a.key, b.key)
+	})
+
+	return sorted
+}
+
+func printStats(stats activityStats, output string) error {
+	if output == render.OUTPUT_JSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Total events\t%d\n", stats.TotalEvents)
+	fmt.Fprintf(w, "Commits pushed\t%d\n", stats.CommitsPushed)
+	fmt.Fprintf(w, "Pull requests opened\t%d\n", stats.PullRequestsOpened)
+	fmt.Fprintf(w, "Pull requests merged\t%d\n", stats.PullRequestsMerged)
+	fmt.Fprintf(w, "Issues opened\t%d\n", stats.IssuesOpened)
+	fmt.Fprintf(w, "Issues closed\t%d\n", stats.IssuesClosed)
+	fmt.Fprintf(w, "Most active repo\t%s\n", valueOrNone(stats.MostActiveRepo))
+	fmt.Fprintf(w, "Most active day\t%s\n", valueOrNone(stats.MostActiveDay))
+
+	fmt.Fprintln(w, "\nBy event type")
+	for _, count := range sortedCounts(stats.ByType) {
+		fmt.Fprintf(w, "  %s\t%d\n", count.key, count.count)
+	}
+
+	fmt.Fprintln(w, "\nBy repository")
+	for _, count := range sortedCounts(stats.ByRepo) {
+		fmt.Fprintf(w, "  %s\t%d\n", count.key, count.count)
+	}
+
+	return w.Flush()
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+	"github.com/dmitriy-zverev/github-activity/render"
+)
+
+func createStatsTestData(t *testing.T) []events.Event {
+	return []events.Event{
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"a/one"},"payload":{"size":3},"created_at":"2025-03-02T12:00:00Z"}`),
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"a/one"},"payload":{"commits":[{"message":"x"},{"message":"y"}]},"created_at":"2025-03-02T11:00:00Z"}`),
+		eventFromJSON(t, `{"type":"PullRequestEvent","repo":{"name":"a/two"},"payload":{"action":"opened"},"created_at":"2025-03-02T10:00:00Z"}`),
+		eventFromJSON(t, `{"type":"PullRequestEvent","repo":{"name":"a/two"},"payload":{"action":"closed","pull_request":{"merged":true}},"created_at":"2025-03-01T12:00:00Z"}`),
+		eventFromJSON(t, `{"type":"PullRequestEvent","repo":{"name":"a/two"},"payload":{"action":"closed","pull_request":{"merged":false}},"created_at":"2025-03-01T12:00:00Z"}`),
+		eventFromJSON(t, `{"type":"IssuesEvent","repo":{"name":"a/one"},"payload":{"action":"opened"},"created_at":"2025-03-01T12:00:00Z"}`),
+		eventFromJSON(t, `{"type":"IssuesEvent","repo":{"name":"a/three"},"payload":{"action":"closed"},"created_at":"2025-03-01T12:00:00Z"}`),
+	}
+}
+
+func TestComputeStats(t *testing.T) {
+	stats := computeStats(createStatsTestData(t), time.UTC)
+
+	if stats.TotalEvents != 7 {
+		t.Errorf("Expected 7 events, got %d", stats.TotalEvents)
+	}
+	if stats.CommitsPushed != 5 {
+		t.Errorf("Expected 5 commits pushed, got %d", stats.CommitsPushed)
+	}
+	if stats.PullRequestsOpened != 1 || stats.PullRequestsMerged != 1 {
+		t.Errorf("Expected 1 PR opened and 1 merged, got %d and %d", stats.PullRequestsOpened, stats.PullRequestsMerged)
+	}
+	if stats.IssuesOpened != 1 || stats.IssuesClosed != 1 {
+		t.Errorf("Expected 1 issue opened and 1 closed, got %d and %d", stats.IssuesOpened, stats.IssuesClosed)
+	}
+	if stats.ByType[events.PULL_REQUEST_EVENT] != 3 || stats.ByType[events.PUSH_EVENT] != 2 {
+		t.Errorf("Unexpected by type counts: %v", stats.ByType)
+	}
+	if stats.ByRepo["a/one"] != 3 || stats.ByRepo["a/two"] != 3 || stats.ByRepo["a/three"] != 1 {
+		t.Errorf("Unexpected by repo counts: %v", stats.ByRepo)
+	}
+	// a/one and a/two are tied, ties are broken by name
+	if stats.MostActiveRepo != "a/one" {
+		t.Errorf("Expected most active repo a/one, got %s", stats.MostActiveRepo)
+	}
+	if stats.MostActiveDay != "2025-03-01" {
+		t.Errorf("Expected most active day 2025-03-01, got %s", stats.MostActiveDay)
+	}
+
+	// The four events at noon UTC fall on the previous evening in UTC-13
+	stats = computeStats(createStatsTestData(t), time.FixedZone("UTC-13", -13*3600))
+	if stats.MostActiveDay != "2025-02-28" {
+		t.Errorf("Expected most active day 2025-02-28 in UTC-13, got %s", stats.MostActiveDay)
+	}
+}
+
+func TestComputeStatsEmpty(t *testing.T) {
+	stats := computeStats([]events.Event{}, time.UTC)
+
+	if stats.TotalEvents != 0 || stats.MostActiveRepo != "" || stats.MostActiveDay != "" {
+		t.Errorf("Expected empty stats, got %+v", stats)
+	}
+}
+
+func TestPrintStats(t *testing.T) {
+	stats := computeStats(createStatsTestData(t), time.UTC)
+
+	output, err := captureStdout(func() error {
+		return printStats(stats, render.OUTPUT_TEXT)
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, expected := range []string{"Total events          7", "Most active repo      a/one", "  PullRequestEvent", "By repository"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	output, err = captureStdout(func() error {
+		return printStats(stats, render.OUTPUT_JSON)
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var decoded activityStats
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	if decoded.CommitsPushed != 5 || decoded.ByRepo["a/two"] != 3 {
+		t.Errorf("Unexpected decoded stats: %+v", decoded)
+	}
+}
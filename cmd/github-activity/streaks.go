@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/client"
+	"github.com/dmitriy-zverev/github-activity/events"
+	"github.com/dmitriy-zverev/github-activity/filter"
+	"github.com/dmitriy-zverev/github-activity/render"
+)
+
+type activityStreaks struct {
+	ActiveDays    int           `json:"active_days"`
+	CurrentStreak int           `json:"current_streak"`
+	LongestStreak int           `json:"longest_streak"`
+	LongestStart  string        `json:"longest_streak_start,omitempty"`
+	LongestEnd    string        `json:"longest_streak_end,omitempty"`
+	Gaps          []activityGap `json:"gaps"`
+}
+
+type activityGap struct {
+	Start   string `json:"start"`
+	End     string `json:"end"`
+	Days    int    `json:"days"`
+	Ongoing bool   `json:"ongoing,omitempty"`
+}
+
+func runStreaks(ctx context.Context, args []string) error {
+	username, args, ok := splitUsername(args)
+	if !ok {
+		return errUsage
+	}
+
+	opts, err := parseOptions(args)
+	if err != nil {
+		return usageError("parsing %v", err)
+	}
+
+	ctx, cancel := opts.fetchContext(ctx)
+	defer cancel()
+
+	gapThreshold := DEFAULT_GAP_DAYS
+	if value, ok := argValue(args, "--gap-days"); ok {
+		gapThreshold, err = strconv.Atoi(value)
+		if err != nil || gapThreshold < 1 {
+			return usageError("parsing gap days: %v is not a positive number", value)
+		}
+	}
+
+	if opts.output == render.OUTPUT_TEXT && opts.online() {
+		fmt.Fprintf(os.Stderr, "Fetching up to %d events for '%s'...\n", client.MAX_EVENTS, opts.qualify(username))
+		fmt.Fprintln(os.Stderr, opts.credentials)
+	}
+
+	activities, err := allUserActivities(ctx, username, opts)
+	if err != nil {
+		return fmt.Errorf("fetching user activity: %w", err)
+	}
+
+	if opts.online() {
+		// Archived history extends streaks past the window the API exposes
+		archived, err := opts.archive().users().load(username)
+		if err != nil {
+			return fmt.Errorf("loading archived activity: %w", err)
+		}
+		activities = events.Merge(activities, archived)
+	}
+
+	activities, err = filter.Apply(activities, opts.filters)
+	if err != nil {
+		return filterError(err)
+	}
+
+	streaks := computeStreaks(activities, time.Now(), opts.location, gapThreshold)
+
+	if opts.output == render.OUTPUT_JSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(streaks); err != nil {
+			return fmt.Errorf("printing user streaks: %w", err)
+		}
+		return nil
+	}
+
+	printStreaks(os.Stdout, streaks, gapThreshold)
+	return nil
+}
+
+func computeStreaks(activities []events.Event, now time.Time, location *time.Location, gapThreshold int) activityStreaks {
+	streaks := activityStreaks{Gaps: []activityGap{}}
+
+	active := map[string]bool{}
+	var first, last time.Time
+	for _, activity := range activities {
+		if activity.CreatedAt.IsZero() {
+			continue
+		}
+
+		day := startOfDay(activity.CreatedAt.In(location))
+		active[day.Format(DAY_FORMAT)] = true
+
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+		if day.After(last) {
+			last = day
+		}
+	}
+
+	streaks.ActiveDays = len(active)
+	if len(active) < 1 {
+		return streaks
+	}
+
+	run := 0
+	var runStart, prevActive time.Time
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if !active[day.Format(DAY_FORMAT)] {
+			run = 0
+			continue
+		}
+
+		if run == 0 {
+			runStart = day
+			if gapDays := daysBetween(prevActive, day) - 1; !prevActive.IsZero() && gapDays > gapThreshold {
+				streaks.Gaps = append(streaks.Gaps, activityGap{
+					Start: prevActive.AddDate(0, 0, 1).Format(DAY_FORMAT),
+					End:   day.AddDate(0, 0, -1).Format(DAY_FORMAT),
+					Days:  gapDays,
+				})
+			}
+		}
+		run++
+		prevActive = day
+
+		if run > streaks.LongestStreak {
+			streaks.LongestStreak = run
+			streaks.LongestStart = runStart.Format(DAY_FORMAT)
+			streaks.LongestEnd = day.Format(DAY_FORMAT)
+		}
+	}
+
+	// A streak is still current when the last active day is today or
+	// yesterday, so a day without activity yet doesn't reset it.
+	today := startOfDay(now.In(location))
+	if sinceLast := daysBetween(last, today); sinceLast <= 1 {
+		streaks.CurrentStreak = run
+	} else if sinceLast > gapThreshold {
+		streaks.Gaps = append(streaks.Gaps, activityGap{
+			Start:   last.AddDate(0, 0, 1).Format(DAY_FORMAT),
+			End:     today.Format(DAY_FORMAT),
+			Days:    sinceLast,
+			Ongoing: true,
+		})
+	}
+
+	return streaks
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func daysBetween(from, to time.Time) int {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func printStreaks(w io.Writer, streaks activityStreaks, gapThreshold int) {
+	fmt.Fprintf(w, "Active days: %d\n", streaks.ActiveDays)
+	fmt.Fprintf(w, "Current streak: %d days\n", streaks.CurrentStreak)
+	if streaks.LongestStreak > 0 {
+		fmt.Fprintf(
+			w,
+			"Longest streak: %d days (%s to %s)\n",
+			streaks.LongestStreak,
+			streaks.LongestStart,
+			streaks.LongestEnd,
+		)
+	} else {
+		fmt.Fprintln(w, "Longest streak: 0 days")
+	}
+
+	if len(streaks.Gaps) < 1 {
+		fmt.Fprintf(w, "No gaps longer than %d inactive days.\n", gapThreshold)
+		return
+	}
+
+	fmt.Fprintf(w, "Gaps longer than %d inactive days:\n", gapThreshold)
+	for _, gap := range streaks.Gaps {
+		ongoing := ""
+		if gap.Ongoing {
+			ongoing = ", ongoing"
+		}
+		fmt.Fprintf(w, "  - %s to %s (%d days%s)\n", gap.Start, gap.End, gap.Days, ongoing)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+func eventsOnDays(t *testing.T, days ...string) []events.Event {
+	var activities []events.Event
+	for _, day := range days {
+		activities = append(activities, eventFromJSON(t, fmt.Sprintf(`{"type":"PushEvent","created_at":"%sT12:00:00Z"}`, day)))
+	}
+	return activities
+}
+
+func TestComputeStreaks(t *testing.T) {
+	activities := eventsOnDays(t,
+		"2025-03-20", "2025-03-19", "2025-03-18",
+		"2025-03-10", "2025-03-09", "2025-03-08", "2025-03-07", "2025-03-07",
+		"2025-03-04",
+	)
+	now := time.Date(2025, 3, 21, 9, 0, 0, 0, time.UTC)
+
+	streaks := computeStreaks(activities, now, time.UTC, 2)
+
+	if streaks.ActiveDays != 8 {
+		t.Errorf("Expected 8 active days, got %d", streaks.ActiveDays)
+	}
+	if streaks.CurrentStreak != 3 {
+		t.Errorf("Expected current streak of 3, got %d", streaks.CurrentStreak)
+	}
+	if streaks.LongestStreak != 4 || streaks.LongestStart != "2025-03-07" || streaks.LongestEnd != "2025-03-10" {
+		t.Errorf("Unexpected longest streak: %+v", streaks)
+	}
+
+	// The 2 day gap between 03-04 and 03-07 is not longer than the threshold
+	expectedGaps := []activityGap{{Start: "2025-03-11", End: "2025-03-17", Days: 7}}
+	if fmt.Sprint(streaks.Gaps) != fmt.Sprint(expectedGaps) {
+		t.Errorf("Expected gaps %v, got %v", expectedGaps, streaks.Gaps)
+	}
+}
+
+func TestComputeStreaksOngoingGap(t *testing.T) {
+	activities := eventsOnDays(t, "2025-03-10", "2025-03-09")
+	now := time.Date(2025, 3, 15, 9, 0, 0, 0, time.UTC)
+
+	streaks := computeStreaks(activities, now, time.UTC, 3)
+
+	if streaks.CurrentStreak != 0 {
+		t.Errorf("Expected no current streak, got %d", streaks.CurrentStreak)
+	}
+	if len(streaks.Gaps) != 1 || !streaks.Gaps[0].Ongoing || streaks.Gaps[0].Days != 5 {
+		t.Errorf("Expected one ongoing 5 day gap, got %v", streaks.Gaps)
+	}
+}
+
+func TestComputeStreaksTimezone(t *testing.T) {
+	// 23:30 UTC on consecutive days falls on the following days in UTC+1
+	activities := []events.Event{
+		eventFromJSON(t, `{"type":"PushEvent","created_at":"2025-03-10T23:30:00Z"}`),
+		eventFromJSON(t, `{"type":"PushEvent","created_at":"2025-03-11T12:00:00Z"}`),
+	}
+	now := time.Date(2025, 3, 11, 13, 0, 0, 0, time.UTC)
+
+	if streaks := computeStreaks(activities, now, time.UTC, 3); streaks.CurrentStreak != 2 {
+		t.Errorf("Expected 2 day streak in UTC, got %d", streaks.CurrentStreak)
+	}
+	if streaks := computeStreaks(activities, now, time.FixedZone("UTC+1", 3600), 3); streaks.CurrentStreak != 1 {
+		t.Errorf("Expected 1 day streak in UTC+1, got %d", streaks.CurrentStreak)
+	}
+}
+
+func TestComputeStreaksEmpty(t *testing.T) {
+	streaks := computeStreaks([]events.Event{}, time.Now(), time.UTC, 3)
+	if streaks.ActiveDays != 0 || streaks.LongestStreak != 0 || len(streaks.Gaps) != 0 {
+		t.Errorf("Expected empty streaks, got %+v", streaks)
+	}
+}
+
+func TestPrintStreaks(t *testing.T) {
+	var buf bytes.Buffer
+	printStreaks(&buf, activityStreaks{
+		ActiveDays:    5,
+		CurrentStreak: 2,
+		LongestStreak: 3,
+		LongestStart:  "2025-03-01",
+		LongestEnd:    "2025-03-03",
+		Gaps:          []activityGap{{Start: "2025-03-04", End: "2025-03-09", Days: 6, Ongoing: true}},
+	}, 3)
+
+	expected := "Active days: 5\n" +
+		"Current streak: 2 days\n" +
+		"Longest streak: 3 days (2025-03-01 to 2025-03-03)\n" +
+		"Gaps longer than 3 inactive days:\n" +
+		"  - 2025-03-04 to 2025-03-09 (6 days, ongoing)\n"
+	if buf.String() != expected {
+		t.Errorf("Expected output %q, got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+	"github.com/dmitriy-zverev/github-activity/filter"
+	"github.com/dmitriy-zverev/github-activity/render"
+)
+
+type teamRoster struct {
+	Name    string       `json:"name"`
+	Members []teamMember `json:"members"`
+}
+
+type teamMember struct {
+	Username string   `json:"username"`
+	Name     string   `json:"name"`
+	Repos    []string `json:"repos"`
+}
+
+func runTeam(ctx context.Context, args []string) error {
+	if len(args) < 1 || strings.HasPrefix(args[0], "-") {
+		return errUsage
+	}
+
+	opts, err := parseOptions(args[1:])
+	if err != nil {
+		return usageError("parsing %v", err)
+	}
+
+	roster, err := opts.teamRoster(args[0])
+	if err != nil {
+		return fmt.Errorf("loading team roster: %w", err)
+	}
+
+	ctx, cancel := opts.fetchContext(ctx)
+	defer cancel()
+
+	if opts.input != "" {
+		return usageError("parsing input: --input can't be used with team")
+	}
+
+	if opts.output == render.OUTPUT_TEXT && opts.online() {
+		fmt.Fprintf(
+			os.Stderr,
+			"Fetching activity for %d members of '%s' at page %s with %s per page events...\n",
+			len(roster.Members),
+			roster.Name,
+			opts.page,
+			opts.perPage,
+		)
+		fmt.Fprintln(os.Stderr, opts.credentials)
+	}
+
+	fetch := func(username string) ([]events.Event, error) {
+		return userActivities(ctx, username, opts)
+	}
+
+	results := fetchUsers(roster.usernames(), fetch)
+	for _, result := range results {
+		if result.err != nil {
+			return fmt.Errorf("fetching activity for '%s': %w", opts.qualify(result.username), result.err)
+		}
+	}
+
+	activities, err := filter.Apply(mergeTeamActivities(roster, results), opts.filters)
+	if err != nil {
+		return filterError(err)
+	}
+
+	renderOpts := opts.renderOptions()
+	renderOpts.ActorNames = roster.displayNames()
+
+	if err := render.Print(os.Stdout, activities, renderOpts); err != nil {
+		return fmt.Errorf("printing team activity: %w", err)
+	}
+	return nil
+}
+
+func loadTeamRoster(path string) (teamRoster, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return teamRoster{}, err
+	}
+
+	var roster teamRoster
+	if err := json.Unmarshal(data, &roster); err != nil {
+		return teamRoster{}, fmt.Errorf("couldn't decode %s: %v", path, err)
+	}
+
+	return roster.validate(DEFAULT_TEAM_NAME)
+}
+
+// validate checks that every member has a username and names an unnamed
+// roster defaultName.
+func (roster teamRoster) validate(defaultName string) (teamRoster, error) {
+	if len(roster.Members) < 1 {
+		return teamRoster{}, errors.New("team has no members")
+	}
+	for i, member := range roster.Members {
+		if member.Username == "" {
+			return teamRoster{}, fmt.Errorf("member %d has no username", i+1)
+		}
+	}
+	if roster.Name == "" {
+		roster.Name = defaultName
+	}
+
+	return roster, nil
+}
+
+func (roster teamRoster) usernames() []string {
+	var usernames []string
+	for _, member := range roster.Members {
+		usernames = append(usernames, member.Username)
+	}
+	return usernames
+}
+
+func (roster teamRoster) displayNames() map[string]string {
+	names := map[string]string{}
+	for _, member := range roster.Members {
+		names[strings.ToLower(member.Username)] = cmp.Or(member.Name, member.Username)
+	}
+	return names
+}
+
+// mergeTeamActivities keeps each member's events within their repo scope and
+// merges them into a single newest-first feed.
+func mergeTeamActivities(roster teamRoster, results []userFetchResult) []events.Event {
+	var activities []events.Event
+
+	for i, result := range results {
+		repos := roster.Members[i].Repos
+
+		for _, activity := range result.activities {
+			if len(repos) > 0 && !slices.ContainsFunc(repos, func(repo string) bool {
+				return strings.EqualFold(repo, activity.Repo.Name)
+			}) {
+				continue
+			}
+			if activity.Actor.Login == "" {
+				activity.Actor.Login = result.username
+			}
+			activities = append(activities, activity)
+		}
+	}
+
+	events.SortNewestFirst(activities)
+
+	return activities
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+	"github.com/dmitriy-zverev/github-activity/render"
+)
+
+func writeTestFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Couldn't write test file: %v", err)
+	}
+	return path
+}
+
+func TestLoadTeamRoster(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expectError bool
+		expectName  string
+	}{
+		{
+			name:       "Valid roster",
+			content:    `{"name":"platform","members":[{"username":"alice","name":"Alice","repos":["org/api"]},{"username":"bob"}]}`,
+			expectName: "platform",
+		},
+		{
+			name:       "Default team name",
+			content:    `{"members":[{"username":"alice"}]}`,
+			expectName: DEFAULT_TEAM_NAME,
+		},
+		{
+			name:        "No members",
+			content:     `{"name":"empty","members":[]}`,
+			expectError: true,
+		},
+		{
+			name:        "Member without username",
+			content:     `{"members":[{"name":"Alice"}]}`,
+			expectError: true,
+		},
+		{
+			name:        "Invalid JSON",
+			content:     `members: [alice]`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roster, err := loadTeamRoster(writeTestFile(t, "team.json", tt.content))
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if roster.Name != tt.expectName {
+				t.Errorf("Expected team name %s, got %s", tt.expectName, roster.Name)
+			}
+		})
+	}
+
+	if _, err := loadTeamRoster(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected error for missing roster file")
+	}
+}
+
+func TestMergeTeamActivities(t *testing.T) {
+	roster := teamRoster{Members: []teamMember{
+		{Username: "alice", Name: "Alice", Repos: []string{"org/api"}},
+		{Username: "bob"},
+	}}
+
+	results := []userFetchResult{
+		{username: "alice", activities: []events.Event{
+			eventFromJSON(t, `{"type":"PushEvent","actor":{"login":"alice"},"repo":{"name":"org/api"},"created_at":"2025-03-02T12:00:00Z"}`),
+			eventFromJSON(t, `{"type":"PushEvent","actor":{"login":"alice"},"repo":{"name":"alice/dotfiles"},"created_at":"2025-03-02T11:00:00Z"}`),
+			eventFromJSON(t, `{"type":"PushEvent","actor":{"login":"alice"},"repo":{"name":"Org/API"},"created_at":"2025-03-01T09:00:00Z"}`),
+		}},
+		{username: "bob", activities: []events.Event{
+			eventFromJSON(t, `{"type":"WatchEvent","repo":{"name":"org/web"},"created_at":"2025-03-03T12:00:00Z"}`),
+			eventFromJSON(t, `{"type":"PushEvent","actor":{"login":"bob"},"repo":{"name":"org/web"},"created_at":"2025-03-01T10:00:00Z"}`),
+		}},
+	}
+
+	merged := mergeTeamActivities(roster, results)
+
+	expectedActors := []string{"bob", "alice", "bob", "alice"}
+	if len(merged) != len(expectedActors) {
+		t.Fatalf("Expected %d merged events, got %d", len(expectedActors), len(merged))
+	}
+	for i, actor := range expectedActors {
+		if merged[i].Actor.Login != actor {
+			t.Errorf("Expected event %d by %s, got %s", i, actor, merged[i].Actor.Login)
+		}
+		if i > 0 && merged[i].CreatedAt.After(merged[i-1].CreatedAt) {
+			t.Errorf("Expected newest-first order at event %d", i)
+		}
+	}
+}
+
+func TestPrinterActorNames(t *testing.T) {
+	roster := teamRoster{Members: []teamMember{{Username: "Alice", Name: "Alice Smith"}, {Username: "bob"}}}
+	activities := []events.Event{
+		eventFromJSON(t, `{"type":"PublicEvent","actor":{"login":"alice"},"repo":{"name":"org/api"}}`),
+		eventFromJSON(t, `{"type":"PublicEvent","actor":{"login":"bob"},"repo":{"name":"org/web"}}`),
+	}
+
+	output, err := captureStdout(func() error {
+		return render.Print(os.Stdout, activities, render.Options{ActorNames: roster.displayNames()})
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "  - Alice Smith: Repo org/api is now public\n" +
+		"  - bob: Repo org/web is now public\n"
+	if output != expected {
+		t.Errorf("Expected output %q, got %q", expected, output)
+	}
+}
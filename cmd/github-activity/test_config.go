@@ -2,6 +2,10 @@ package main
 
 import (
 	"testing"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+	"github.com/dmitriy-zverev/github-activity/filter"
+	"github.com/dmitriy-zverev/github-activity/render"
 )
 
 // TestConfig contains configuration for running tests
@@ -15,7 +19,7 @@ type TestConfig struct {
 // GetTestConfig returns the default test configuration
 func GetTestConfig() TestConfig {
 	return TestConfig{
-		MockAPIURL:     "http://localhost:8080",
+		MockAPIURL:     "http://" + DEFAULT_FAKE_SERVER_ADDR,
 		TestUsername:   "testuser",
 		TestTimeout:    30,
 		EnableNetTests: false, // Set to true to enable network-dependent tests
@@ -31,16 +35,16 @@ func TestHelpers() {
 // BenchmarkFilterEvents benchmarks the filterEvents function
 func BenchmarkFilterEvents(b *testing.B) {
 	// Create test data
-	testEvents := make([]githubUserData, 1000)
+	testEvents := make([]events.Event, 1000)
 	for i := 0; i < 1000; i++ {
-		eventType := PUSH_EVENT
+		eventType := events.PUSH_EVENT
 		if i%3 == 0 {
-			eventType = PULL_REQUEST_EVENT
+			eventType = events.PULL_REQUEST_EVENT
 		} else if i%3 == 1 {
-			eventType = CREATE_EVENT
+			eventType = events.CREATE_EVENT
 		}
 
-		testEvents[i] = githubUserData{
+		testEvents[i] = events.Event{
 			Type: eventType,
 			Repo: struct {
 				Name string `json:"name"`
@@ -50,45 +54,68 @@ func BenchmarkFilterEvents(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		filterEvents(testEvents, PUSH_EVENT)
+		filter.ByType(testEvents, events.PUSH_EVENT)
 	}
 }
 
 // BenchmarkActivityString benchmarks the activityString function
 func BenchmarkActivityString(b *testing.B) {
-	testActivity := githubUserData{
-		Type: PUSH_EVENT,
+	testActivity := events.Event{
+		Type: events.PUSH_EVENT,
 		Repo: struct {
 			Name string `json:"name"`
 		}{Name: "test-repo"},
 		Payload: struct {
 			Ref     string `json:"ref"`
 			RefType string `json:"ref_type"`
+			Size    int    `json:"size"`
+			Before  string `json:"before"`
+			Head    string `json:"head"`
 			Commits []struct {
+				Sha     string `json:"sha"`
 				Message string `json:"message"`
+				Author  struct {
+					Name string `json:"name"`
+				} `json:"author"`
 			} `json:"commits"`
 			Action string `json:"action"`
+			Number int    `json:"number"`
 			Forkee struct {
 				FullName string `json:"full_name"`
+				HTMLURL  string `json:"html_url"`
 				Owner    struct {
 					Login string `json:"login"`
 				} `json:"owner"`
 			} `json:"forkee"`
 			Issue struct {
-				Title string `json:"title"`
+				Number  int    `json:"number"`
+				Title   string `json:"title"`
+				HTMLURL string `json:"html_url"`
 			} `json:"issue"`
 			PullReq struct {
-				Title string `json:"title"`
+				Title   string `json:"title"`
+				Merged  bool   `json:"merged"`
+				HTMLURL string `json:"html_url"`
 			} `json:"pull_request"`
 			Member struct {
 				Login string `json:"login"`
 			} `json:"member"`
 			Release struct {
-				Name string `json:"name"`
+				Name    string `json:"name"`
+				TagName string `json:"tag_name"`
+				HTMLURL string `json:"html_url"`
 			} `json:"release"`
+			Comment struct {
+				Body    string `json:"body"`
+				HTMLURL string `json:"html_url"`
+			} `json:"comment"`
 		}{
 			Commits: []struct {
+				Sha     string `json:"sha"`
 				Message string `json:"message"`
+				Author  struct {
+					Name string `json:"name"`
+				} `json:"author"`
 			}{
 				{Message: "commit1"},
 				{Message: "commit2"},
@@ -98,7 +125,7 @@ func BenchmarkActivityString(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		activityString(testActivity)
+		render.ActivityString(testActivity)
 	}
 }
 
@@ -120,10 +147,10 @@ func TestPerformance(t *testing.T) {
 	}
 
 	// Test filter performance with large dataset
-	largeDataset := make([]githubUserData, 10000)
+	largeDataset := make([]events.Event, 10000)
 	for i := 0; i < 10000; i++ {
-		largeDataset[i] = githubUserData{
-			Type: PUSH_EVENT,
+		largeDataset[i] = events.Event{
+			Type: events.PUSH_EVENT,
 			Repo: struct {
 				Name string `json:"name"`
 			}{Name: "test-repo"},
@@ -131,7 +158,7 @@ func TestPerformance(t *testing.T) {
 	}
 
 	// This should complete quickly
-	result := filterEvents(largeDataset, PUSH_EVENT)
+	result := filter.ByType(largeDataset, events.PUSH_EVENT)
 	if len(result) != 10000 {
 		t.Errorf("Expected 10000 filtered events, got %d", len(result))
 	}
@@ -141,8 +168,8 @@ func TestPerformance(t *testing.T) {
 func TestEdgeCases(t *testing.T) {
 	t.Run("Empty data structures", func(t *testing.T) {
 		// Test with empty events
-		emptyEvents := []githubUserData{}
-		filtered := filterEvents(emptyEvents, PUSH_EVENT)
+		emptyEvents := []events.Event{}
+		filtered := filter.ByType(emptyEvents, events.PUSH_EVENT)
 		if len(filtered) != 0 {
 			t.Errorf("Expected 0 filtered events from empty input, got %d", len(filtered))
 		}
@@ -150,14 +177,14 @@ func TestEdgeCases(t *testing.T) {
 
 	t.Run("Nil payload fields", func(t *testing.T) {
 		// Test activity string with minimal data
-		minimalActivity := githubUserData{
+		minimalActivity := events.Event{
 			Type: "UnknownEvent",
 			Repo: struct {
 				Name string `json:"name"`
 			}{Name: "test-repo"},
 		}
 
-		result, err := activityString(minimalActivity)
+		result, err := render.ActivityString(minimalActivity)
 		if err != nil {
 			t.Errorf("Unexpected error for minimal activity: %v", err)
 		}
@@ -173,44 +200,67 @@ func TestEdgeCases(t *testing.T) {
 			longName[i] = 'a'
 		}
 
-		longActivity := githubUserData{
-			Type: PUSH_EVENT,
+		longActivity := events.Event{
+			Type: events.PUSH_EVENT,
 			Repo: struct {
 				Name string `json:"name"`
 			}{Name: string(longName)},
 			Payload: struct {
 				Ref     string `json:"ref"`
 				RefType string `json:"ref_type"`
+				Size    int    `json:"size"`
+				Before  string `json:"before"`
+				Head    string `json:"head"`
 				Commits []struct {
+					Sha     string `json:"sha"`
 					Message string `json:"message"`
+					Author  struct {
+						Name string `json:"name"`
+					} `json:"author"`
 				} `json:"commits"`
 				Action string `json:"action"`
+				Number int    `json:"number"`
 				Forkee struct {
 					FullName string `json:"full_name"`
+					HTMLURL  string `json:"html_url"`
 					Owner    struct {
 						Login string `json:"login"`
 					} `json:"owner"`
 				} `json:"forkee"`
 				Issue struct {
-					Title string `json:"title"`
+					Number  int    `json:"number"`
+					Title   string `json:"title"`
+					HTMLURL string `json:"html_url"`
 				} `json:"issue"`
 				PullReq struct {
-					Title string `json:"title"`
+					Title   string `json:"title"`
+					Merged  bool   `json:"merged"`
+					HTMLURL string `json:"html_url"`
 				} `json:"pull_request"`
 				Member struct {
 					Login string `json:"login"`
 				} `json:"member"`
 				Release struct {
-					Name string `json:"name"`
+					Name    string `json:"name"`
+					TagName string `json:"tag_name"`
+					HTMLURL string `json:"html_url"`
 				} `json:"release"`
+				Comment struct {
+					Body    string `json:"body"`
+					HTMLURL string `json:"html_url"`
+				} `json:"comment"`
 			}{
 				Commits: []struct {
+					Sha     string `json:"sha"`
 					Message string `json:"message"`
+					Author  struct {
+						Name string `json:"name"`
+					} `json:"author"`
 				}{{Message: "test"}},
 			},
 		}
 
-		result, err := activityString(longActivity)
+		result, err := render.ActivityString(longActivity)
 		if err != nil {
 			t.Errorf("Unexpected error for long activity: %v", err)
 		}
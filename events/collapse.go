@@ -0,0 +1,35 @@
+package events
+
+// Collapse merges runs of consecutive events with the same type, repo and
+// actor into the first event of the run, keeping the run in Collapsed.
+func Collapse(events []Event) []Event {
+	var newEvents []Event
+
+	for _, event := range events {
+		last := len(newEvents) - 1
+		if last >= 0 && similarEvents(newEvents[last], event) {
+			if len(newEvents[last].Collapsed) == 0 {
+				newEvents[last].Collapsed = []Event{newEvents[last]}
+			}
+			newEvents[last].Collapsed = append(newEvents[last].Collapsed, event)
+			continue
+		}
+
+		newEvents = append(newEvents, event)
+	}
+
+	return newEvents
+}
+
+func similarEvents(a, b Event) bool {
+	return a.Type == b.Type && a.Repo.Name == b.Repo.Name && a.Actor.Login == b.Actor.Login
+}
+
+// ExpandCollapsed returns the run merged into userActivity, or userActivity
+// itself when nothing was merged.
+func ExpandCollapsed(userActivity Event) []Event {
+	if len(userActivity.Collapsed) > 0 {
+		return userActivity.Collapsed
+	}
+	return []Event{userActivity}
+}
@@ -0,0 +1,22 @@
+package events
+
+import "testing"
+
+func TestCollapseEventsEmpty(t *testing.T) {
+	if result := Collapse([]Event{}); len(result) != 0 {
+		t.Errorf("Expected no events, got %d", len(result))
+	}
+}
+
+func TestCollapseEventsKeepsActorsApart(t *testing.T) {
+	events := []Event{
+		eventFromJSON(t, `{"type":"PushEvent","actor":{"login":"alice"},"repo":{"name":"x/y"}}`),
+		eventFromJSON(t, `{"type":"PushEvent","actor":{"login":"bob"},"repo":{"name":"x/y"}}`),
+		eventFromJSON(t, `{"type":"PushEvent","actor":{"login":"bob"},"repo":{"name":"x/y"}}`),
+	}
+
+	result := Collapse(events)
+	if len(result) != 2 || len(result[1].Collapsed) != 2 {
+		t.Errorf("Expected only bob's pushes to collapse, got %d events", len(result))
+	}
+}
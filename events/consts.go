@@ -1,4 +1,4 @@
-package main
+package events
 
 const (
 	PUSH_EVENT           = "PushEvent"
@@ -14,8 +14,24 @@ const (
 	RELEASE_EVENT        = "ReleaseEvent"
 )
 
+// EVENT_TYPES lists the event types this module knows how to describe, in
+// the order shown by help and shell completion.
+var EVENT_TYPES = []string{
+	PUSH_EVENT,
+	PULL_REQUEST_EVENT,
+	CREATE_EVENT,
+	WATCH_EVENT,
+	DELETE_EVENT,
+	FORK_EVENT,
+	ISSUES_EVENT,
+	ISSUES_COMMENT_EVENT,
+	PUBLIC_EVENT,
+	MEMBER_EVENT,
+	RELEASE_EVENT,
+}
+
 const (
-	DEFAULT_PAGE_NUM        = "1"
-	DEFAULT_PER_PAGE_EVENTS = "30"
-	DEFAULT_FILTER_TYPE     = ""
+	DEFAULT_WEB_HOST = "github.com"
+	BRANCH_REF_TYPE  = "branch"
+	TAG_REF_TYPE     = "tag"
 )
@@ -0,0 +1,46 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// Decode accepts both a JSON array of events, as returned by the Events
+// API, and newline delimited JSON with one event per line.
+func Decode(r io.Reader) ([]Event, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return []Event{}, err
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return []Event{}, nil
+	}
+
+	if data[0] == '[' {
+		var events []Event
+		if err := json.Unmarshal(data, &events); err != nil {
+			return []Event{}, err
+		}
+		return events, nil
+	}
+
+	var events []Event
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var event Event
+		err := decoder.Decode(&event)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return []Event{}, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
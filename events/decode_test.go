@@ -0,0 +1,50 @@
+package events
+
+import (
+	"strings"
+	"testing"
+)
+
+const testEventsArray = `[
+	{"id":"2","type":"PushEvent","actor":{"login":"octocat"},"repo":{"name":"a/b"},"payload":{"commits":[{"message":"Fix JIRA-1"}]}},
+	{"id":"1","type":"WatchEvent","actor":{"login":"octocat"},"repo":{"name":"a/c"},"payload":{"action":"started"}}
+]`
+
+const testEventsNDJSON = `{"id":"2","type":"PushEvent","repo":{"name":"a/b"},"payload":{"commits":[{"message":"Fix JIRA-1"}]}}
+{"id":"1","type":"WatchEvent","repo":{"name":"a/c"},"payload":{"action":"started"}}
+`
+
+func TestDecodeEvents(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectError   bool
+		expectedCount int
+	}{
+		{name: "JSON array", input: testEventsArray, expectedCount: 2},
+		{name: "NDJSON", input: testEventsNDJSON, expectedCount: 2},
+		{name: "Single object", input: `{"type":"PushEvent"}`, expectedCount: 1},
+		{name: "Empty input", input: "  \n", expectedCount: 0},
+		{name: "Empty array", input: "[]", expectedCount: 0},
+		{name: "Invalid array", input: `[{"type":`, expectError: true},
+		{name: "Invalid line", input: "{\"type\":\"PushEvent\"}\nnot json\n", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := Decode(strings.NewReader(tt.input))
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(events) != tt.expectedCount {
+				t.Errorf("Expected %d events, got %d", tt.expectedCount, len(events))
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Package events holds the GitHub event model shared by the client, filter
+// and render packages, along with helpers to decode, merge and collapse
+// lists of events.
+package events
+
+import "time"
+
+// Event is a single entry of the GitHub Events API.
+type Event struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Actor     struct {
+		Login string `json:"login"`
+		Type  string `json:"type"`
+	} `json:"actor"`
+	Repo struct {
+		Name string `json:"name"`
+	} `json:"repo"`
+	Payload struct {
+		Ref     string `json:"ref"`
+		RefType string `json:"ref_type"`
+		Size    int    `json:"size"`
+		Before  string `json:"before"`
+		Head    string `json:"head"`
+		Commits []struct {
+			Sha     string `json:"sha"`
+			Message string `json:"message"`
+			Author  struct {
+				Name string `json:"name"`
+			} `json:"author"`
+		} `json:"commits"`
+		Action string `json:"action"`
+		Number int    `json:"number"`
+		Forkee struct {
+			FullName string `json:"full_name"`
+			HTMLURL  string `json:"html_url"`
+			Owner    struct {
+				Login string `json:"login"`
+			} `json:"owner"`
+		} `json:"forkee"`
+		Issue struct {
+			Number  int    `json:"number"`
+			Title   string `json:"title"`
+			HTMLURL string `json:"html_url"`
+		} `json:"issue"`
+		PullReq struct {
+			Title   string `json:"title"`
+			Merged  bool   `json:"merged"`
+			HTMLURL string `json:"html_url"`
+		} `json:"pull_request"`
+		Member struct {
+			Login string `json:"login"`
+		} `json:"member"`
+		Release struct {
+			Name    string `json:"name"`
+			TagName string `json:"tag_name"`
+			HTMLURL string `json:"html_url"`
+		} `json:"release"`
+		Comment struct {
+			Body    string `json:"body"`
+			HTMLURL string `json:"html_url"`
+		} `json:"comment"`
+	} `json:"payload"`
+
+	// Collapsed holds the run of similar events merged into this one by
+	// Collapse. It is empty for events that were not merged.
+	Collapsed []Event `json:"-"`
+}
+
+// PushCommitCount returns how many commits a push contains. GitHub only
+// lists the first commits of large pushes, so the payload size wins.
+func PushCommitCount(event Event) int {
+	return max(event.Payload.Size, len(event.Payload.Commits))
+}
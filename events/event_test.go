@@ -0,0 +1,76 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func eventFromJSON(t *testing.T, data string) Event {
+	t.Helper()
+
+	var event Event
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		t.Fatalf("Couldn't decode test event: %v", err)
+	}
+
+	return event
+}
+
+func TestPushCommitCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    string
+		expected int
+	}{
+		{name: "Listed commits", event: `{"payload":{"commits":[{"sha":"a"},{"sha":"b"}]}}`, expected: 2},
+		{name: "Truncated push", event: `{"payload":{"size":25,"commits":[{"sha":"a"}]}}`, expected: 25},
+		{name: "Empty payload", event: `{}`, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if count := PushCommitCount(eventFromJSON(t, tt.event)); count != tt.expected {
+				t.Errorf("Expected %d commits, got %d", tt.expected, count)
+			}
+		})
+	}
+}
+
+func TestEventTypeConstants(t *testing.T) {
+	// Test that event type constants are defined correctly
+	expectedConstants := map[string]string{
+		"PUSH_EVENT":           "PushEvent",
+		"PULL_REQUEST_EVENT":   "PullRequestEvent",
+		"CREATE_EVENT":         "CreateEvent",
+		"WATCH_EVENT":          "WatchEvent",
+		"DELETE_EVENT":         "DeleteEvent",
+		"FORK_EVENT":           "ForkEvent",
+		"ISSUES_EVENT":         "IssuesEvent",
+		"ISSUES_COMMENT_EVENT": "IssueCommentEvent",
+		"PUBLIC_EVENT":         "PublicEvent",
+		"MEMBER_EVENT":         "MemberEvent",
+		"RELEASE_EVENT":        "ReleaseEvent",
+	}
+
+	actualConstants := map[string]string{
+		"PUSH_EVENT":           PUSH_EVENT,
+		"PULL_REQUEST_EVENT":   PULL_REQUEST_EVENT,
+		"CREATE_EVENT":         CREATE_EVENT,
+		"WATCH_EVENT":          WATCH_EVENT,
+		"DELETE_EVENT":         DELETE_EVENT,
+		"FORK_EVENT":           FORK_EVENT,
+		"ISSUES_EVENT":         ISSUES_EVENT,
+		"ISSUES_COMMENT_EVENT": ISSUES_COMMENT_EVENT,
+		"PUBLIC_EVENT":         PUBLIC_EVENT,
+		"MEMBER_EVENT":         MEMBER_EVENT,
+		"RELEASE_EVENT":        RELEASE_EVENT,
+	}
+
+	for name, expected := range expectedConstants {
+		if actual, exists := actualConstants[name]; !exists {
+			t.Errorf("Constant %s is not defined", name)
+		} else if actual != expected {
+			t.Errorf("Expected %s to be %s, got %s", name, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package events
+
+import "slices"
+
+// Merge combines event lists, dropping events whose ID was already seen, and
+// returns them newest first.
+func Merge(lists ...[]Event) []Event {
+	var events []Event
+	seen := map[string]bool{}
+
+	for _, list := range lists {
+		for _, event := range list {
+			if event.ID != "" && seen[event.ID] {
+				continue
+			}
+			seen[event.ID] = true
+			events = append(events, event)
+		}
+	}
+
+	SortNewestFirst(events)
+
+	return events
+}
+
+// SortNewestFirst orders events by creation time, newest first, keeping the
+// order of events created at the same time.
+func SortNewestFirst(events []Event) {
+	slices.SortStableFunc(events, func(a, b Event) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+}
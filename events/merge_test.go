@@ -0,0 +1,19 @@
+package events
+
+import "testing"
+
+func TestMerge(t *testing.T) {
+	a := []Event{
+		eventFromJSON(t, `{"id":"1","created_at":"2025-03-01T12:00:00Z"}`),
+		eventFromJSON(t, `{"id":"3","created_at":"2025-03-03T12:00:00Z"}`),
+	}
+	b := []Event{
+		eventFromJSON(t, `{"id":"2","created_at":"2025-03-02T12:00:00Z"}`),
+		eventFromJSON(t, `{"id":"3","created_at":"2025-03-03T12:00:00Z"}`),
+	}
+
+	merged := Merge(a, b)
+	if len(merged) != 3 || merged[0].ID != "3" || merged[2].ID != "1" {
+		t.Errorf("Unexpected merge result: %v", merged)
+	}
+}
@@ -0,0 +1,64 @@
+package events
+
+import "fmt"
+
+// HTMLURL returns the web page of what event is about: the pull request,
+// issue, comment, release, fork or pushed commits when the payload says which,
+// and the repository otherwise. host is the GitHub web host, github.com when
+// empty.
+func HTMLURL(event Event, host string) string {
+	if host == "" {
+		host = DEFAULT_WEB_HOST
+	}
+	repo := fmt.Sprintf("https://%s/%s", host, event.Repo.Name)
+	payload := event.Payload
+
+	switch event.Type {
+	case PULL_REQUEST_EVENT:
+		if payload.PullReq.HTMLURL != "" {
+			return payload.PullReq.HTMLURL
+		}
+		if payload.Number != 0 {
+			return fmt.Sprintf("%s/pull/%d", repo, payload.Number)
+		}
+	case ISSUES_COMMENT_EVENT:
+		if payload.Comment.HTMLURL != "" {
+			return payload.Comment.HTMLURL
+		}
+		fallthrough
+	case ISSUES_EVENT:
+		if payload.Issue.HTMLURL != "" {
+			return payload.Issue.HTMLURL
+		}
+		if payload.Issue.Number != 0 {
+			return fmt.Sprintf("%s/issues/%d", repo, payload.Issue.Number)
+		}
+	case RELEASE_EVENT:
+		if payload.Release.HTMLURL != "" {
+			return payload.Release.HTMLURL
+		}
+		if payload.Release.TagName != "" {
+			return fmt.Sprintf("%s/releases/tag/%s", repo, payload.Release.TagName)
+		}
+	case FORK_EVENT:
+		if payload.Forkee.HTMLURL != "" {
+			return payload.Forkee.HTMLURL
+		}
+		if payload.Forkee.FullName != "" {
+			return fmt.Sprintf("https://%s/%s", host, payload.Forkee.FullName)
+		}
+	case PUSH_EVENT:
+		switch {
+		case len(payload.Commits) == 1:
+			return fmt.Sprintf("%s/commit/%s", repo, payload.Commits[0].Sha)
+		case payload.Before != "" && payload.Head != "":
+			return fmt.Sprintf("%s/compare/%s...%s", repo, payload.Before, payload.Head)
+		}
+	case CREATE_EVENT:
+		if payload.RefType == BRANCH_REF_TYPE || payload.RefType == TAG_REF_TYPE {
+			return fmt.Sprintf("%s/tree/%s", repo, payload.Ref)
+		}
+	}
+
+	return repo
+}
@@ -0,0 +1,77 @@
+package events
+
+import "testing"
+
+func TestHTMLURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    string
+		host     string
+		expected string
+	}{
+		{
+			name:     "Pull request URL from the payload",
+			event:    `{"type":"PullRequestEvent","repo":{"name":"o/r"},"payload":{"number":7,"pull_request":{"html_url":"https://github.com/o/r/pull/7"}}}`,
+			expected: "https://github.com/o/r/pull/7",
+		},
+		{
+			name:     "Pull request number",
+			event:    `{"type":"PullRequestEvent","repo":{"name":"o/r"},"payload":{"number":7}}`,
+			expected: "https://github.com/o/r/pull/7",
+		},
+		{
+			name:     "Issue comment",
+			event:    `{"type":"IssueCommentEvent","repo":{"name":"o/r"},"payload":{"issue":{"number":3},"comment":{"html_url":"https://github.com/o/r/issues/3#issuecomment-1"}}}`,
+			expected: "https://github.com/o/r/issues/3#issuecomment-1",
+		},
+		{
+			name:     "Issue comment without comment URL",
+			event:    `{"type":"IssueCommentEvent","repo":{"name":"o/r"},"payload":{"issue":{"number":3}}}`,
+			expected: "https://github.com/o/r/issues/3",
+		},
+		{
+			name:     "Release tag",
+			event:    `{"type":"ReleaseEvent","repo":{"name":"o/r"},"payload":{"release":{"tag_name":"v1.0.0"}}}`,
+			expected: "https://github.com/o/r/releases/tag/v1.0.0",
+		},
+		{
+			name:     "Fork",
+			event:    `{"type":"ForkEvent","repo":{"name":"o/r"},"payload":{"forkee":{"full_name":"me/r"}}}`,
+			expected: "https://github.com/me/r",
+		},
+		{
+			name:     "Single commit push",
+			event:    `{"type":"PushEvent","repo":{"name":"o/r"},"payload":{"commits":[{"sha":"abc"}]}}`,
+			expected: "https://github.com/o/r/commit/abc",
+		},
+		{
+			name:     "Push comparison",
+			event:    `{"type":"PushEvent","repo":{"name":"o/r"},"payload":{"before":"aaa","head":"bbb","commits":[{"sha":"a"},{"sha":"bbb"}]}}`,
+			expected: "https://github.com/o/r/compare/aaa...bbb",
+		},
+		{
+			name:     "Created branch",
+			event:    `{"type":"CreateEvent","repo":{"name":"o/r"},"payload":{"ref":"feature/x","ref_type":"branch"}}`,
+			expected: "https://github.com/o/r/tree/feature/x",
+		},
+		{
+			name:     "Repository fallback",
+			event:    `{"type":"WatchEvent","repo":{"name":"o/r"}}`,
+			expected: "https://github.com/o/r",
+		},
+		{
+			name:     "Enterprise host",
+			event:    `{"type":"PullRequestEvent","repo":{"name":"o/r"},"payload":{"number":7}}`,
+			host:     "ghe.example.com",
+			expected: "https://ghe.example.com/o/r/pull/7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if url := HTMLURL(eventFromJSON(t, tt.event), tt.host); url != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, url)
+			}
+		})
+	}
+}
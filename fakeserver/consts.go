@@ -0,0 +1,9 @@
+package fakeserver
+
+const (
+	DEFAULT_RATE_LIMIT  = 60
+	DEFAULT_FAIL_STATUS = 500
+	DEFAULT_PER_PAGE    = 30
+	MAX_PER_PAGE        = 100
+	FIXTURE_EXT         = ".json"
+)
@@ -0,0 +1,201 @@
+// Package fakeserver serves the GitHub Events API from fixture files so the
+// real client can be exercised without the network.
+package fakeserver
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+// Server mimics the GitHub Events API on top of fixture files. Events are
+// read from <fixtures>/users/<user>.json, <fixtures>/orgs/<org>.json and
+// <fixtures>/repos/<owner>/<repo>.json, as a JSON array or NDJSON.
+type Server struct {
+	// RateLimit is the number of requests allowed per hour
+	RateLimit int
+	// FailEvery makes every n-th request fail with FailStatus when positive
+	FailEvery  int
+	FailStatus int
+
+	fixtures string
+
+	mu       sync.Mutex
+	requests int
+	used     int
+	reset    time.Time
+}
+
+// New returns a Server for the fixtures in dir with GitHub's unauthenticated
+// rate limit and no injected errors.
+func New(dir string) *Server {
+	return &Server{
+		RateLimit:  DEFAULT_RATE_LIMIT,
+		FailStatus: DEFAULT_FAIL_STATUS,
+		fixtures:   dir,
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+
+	remaining, reset, inject := s.countRequest()
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(s.RateLimit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	w.Header().Set("X-RateLimit-Used", strconv.Itoa(s.RateLimit-remaining))
+	w.Header().Set("X-RateLimit-Resource", "core")
+
+	if inject {
+		writeError(w, s.FailStatus, http.StatusText(s.FailStatus))
+		return
+	}
+	if remaining < 0 {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Used", strconv.Itoa(s.RateLimit))
+		writeError(w, http.StatusForbidden, "API rate limit exceeded")
+		return
+	}
+
+	path, ok := s.fixturePath(r.URL.Path)
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	activities, err := events.Decode(file)
+	file.Close()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("bad fixture: %v", err))
+		return
+	}
+
+	page := queryNumber(r.URL.Query(), "page", 1, 0)
+	perPage := queryNumber(r.URL.Query(), "per_page", DEFAULT_PER_PAGE, MAX_PER_PAGE)
+	start := min((page-1)*perPage, len(activities))
+	end := min(start+perPage, len(activities))
+
+	body, err := json.Marshal(activities[start:end])
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	lastPage := max((len(activities)+perPage-1)/perPage, 1)
+	if link := linkHeader(r, page, perPage, lastPage); link != "" {
+		w.Header().Set("Link", link)
+	}
+
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256(body))
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Write(body)
+}
+
+// countRequest records a request and reports the rate limit left after it
+// (negative once exhausted), when the window resets and whether an error
+// should be injected.
+func (s *Server) countRequest() (int, time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.reset.IsZero() || !now.Before(s.reset) {
+		s.used = 0
+		s.reset = now.Add(time.Hour).Truncate(time.Second)
+	}
+
+	s.requests++
+	if s.FailEvery > 0 && s.requests%s.FailEvery == 0 {
+		return s.RateLimit - s.used, s.reset, true
+	}
+
+	s.used++
+	return s.RateLimit - s.used, s.reset, false
+}
+
+func (s *Server) fixturePath(urlPath string) (string, bool) {
+	parts := strings.Split(strings.Trim(urlPath, "/"), "/")
+	for _, part := range parts {
+		if part == "" || part != filepath.Base(part) || strings.HasPrefix(part, ".") {
+			return "", false
+		}
+	}
+
+	switch {
+	case len(parts) == 3 && (parts[0] == "users" || parts[0] == "orgs") && parts[2] == "events":
+		return filepath.Join(s.fixtures, parts[0], strings.ToLower(parts[1])+FIXTURE_EXT), true
+	case len(parts) == 4 && parts[0] == "repos" && parts[3] == "events":
+		return filepath.Join(s.fixtures, parts[0], strings.ToLower(parts[1]), strings.ToLower(parts[2])+FIXTURE_EXT), true
+	default:
+		return "", false
+	}
+}
+
+func queryNumber(query url.Values, name string, fallback, limit int) int {
+	number, err := strconv.Atoi(query.Get(name))
+	if err != nil || number < 1 {
+		return fallback
+	}
+	if limit > 0 {
+		return min(number, limit)
+	}
+	return number
+}
+
+// linkHeader builds a Link header the way GitHub does, leaving out the
+// relations that don't apply to the current page.
+func linkHeader(r *http.Request, page, perPage, lastPage int) string {
+	pageURL := func(page int) string {
+		query := r.URL.Query()
+		query.Set("page", strconv.Itoa(page))
+		query.Set("per_page", strconv.Itoa(perPage))
+		return fmt.Sprintf("http://%s%s?%s", r.Host, r.URL.Path, query.Encode())
+	}
+
+	var links []string
+	if page > 1 {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(min(page-1, lastPage))))
+	}
+	if page < lastPage {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(page+1)))
+		links = append(links, fmt.Sprintf(`<%s>; rel="last"`, pageURL(lastPage)))
+	}
+	if page > 1 {
+		links = append(links, fmt.Sprintf(`<%s>; rel="first"`, pageURL(1)))
+	}
+
+	return strings.Join(links, ", ")
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message":           message,
+		"documentation_url": "https://docs.github.com/rest",
+	})
+}
@@ -0,0 +1,269 @@
+package fakeserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/client"
+)
+
+// writeFixture writes count events for the given fixture path under dir.
+func writeFixture(t *testing.T, dir, name string, count int) {
+	t.Helper()
+
+	var lines []string
+	for i := range count {
+		lines = append(lines, fmt.Sprintf(
+			`{"id":"%d","type":"WatchEvent","repo":{"name":"octo/repo-%d"},"payload":{"action":"started"}}`,
+			i+1,
+			i,
+		))
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatalf("Couldn't create fixture dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600); err != nil {
+		t.Fatalf("Couldn't write fixture: %v", err)
+	}
+}
+
+func newTestServer(t *testing.T, configure func(*Server)) (*httptest.Server, client.Client) {
+	t.Helper()
+
+	dir := t.TempDir()
+	writeFixture(t, dir, "users/octocat.json", 250)
+	writeFixture(t, dir, "orgs/github.json", 3)
+	writeFixture(t, dir, "repos/octo/hello.json", 2)
+
+	fake := New(dir)
+	if configure != nil {
+		configure(fake)
+	}
+
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	return server, client.Client{HTTPClient: server.Client(), BaseURL: server.URL}
+}
+
+func TestServerWithClient(t *testing.T) {
+	_, api := newTestServer(t, nil)
+
+	activities, err := api.FetchUserEvents(context.Background(), "octocat", "2", "10")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(activities) != 10 || activities[0].ID != "11" {
+		t.Errorf("Expected events 11-20 on page 2, got %d events starting at %q", len(activities), activities[0].ID)
+	}
+
+	all, err := api.FetchAllUserEvents(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(all) != 250 {
+		t.Errorf("Expected all 250 events across pages, got %d", len(all))
+	}
+
+	if _, err := api.FetchUserEvents(context.Background(), "ghost", "1", "30"); err == nil {
+		t.Error("Expected an error for an unknown user")
+	}
+}
+
+func TestServerRoutes(t *testing.T) {
+	server, _ := newTestServer(t, nil)
+
+	tests := []struct {
+		path       string
+		wantStatus int
+	}{
+		{"/users/octocat/events", http.StatusOK},
+		{"/users/OctoCat/events", http.StatusOK},
+		{"/orgs/github/events", http.StatusOK},
+		{"/repos/octo/hello/events", http.StatusOK},
+		{"/repos/octo/missing/events", http.StatusNotFound},
+		{"/users/octocat", http.StatusNotFound},
+		{"/users/../events", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			res, err := http.Get(server.URL + tt.path)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			res.Body.Close()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, res.StatusCode)
+			}
+		})
+	}
+}
+
+func TestServerLinkHeader(t *testing.T) {
+	server, _ := newTestServer(t, nil)
+
+	tests := []struct {
+		name     string
+		query    string
+		contains []string
+		missing  []string
+	}{
+		{
+			name:     "First page",
+			query:    "page=1&per_page=100",
+			contains: []string{`page=2&per_page=100>; rel="next"`, `page=3&per_page=100>; rel="last"`},
+			missing:  []string{`rel="prev"`, `rel="first"`},
+		},
+		{
+			name:     "Middle page",
+			query:    "page=2&per_page=100",
+			contains: []string{`rel="prev"`, `rel="next"`, `rel="last"`, `page=1&per_page=100>; rel="first"`},
+		},
+		{
+			name:     "Last page",
+			query:    "page=3&per_page=100",
+			contains: []string{`page=2&per_page=100>; rel="prev"`, `rel="first"`},
+			missing:  []string{`rel="next"`, `rel="last"`},
+		},
+		{
+			name:     "Per page is capped",
+			query:    "per_page=500",
+			contains: []string{`page=3&per_page=100>; rel="last"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := http.Get(server.URL + "/users/octocat/events?" + tt.query)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			res.Body.Close()
+
+			link := res.Header.Get("Link")
+			for _, want := range tt.contains {
+				if !strings.Contains(link, want) {
+					t.Errorf("Expected Link header to contain %q, got %q", want, link)
+				}
+			}
+			for _, unwanted := range tt.missing {
+				if strings.Contains(link, unwanted) {
+					t.Errorf("Expected Link header without %q, got %q", unwanted, link)
+				}
+			}
+		})
+	}
+}
+
+func TestServerETag(t *testing.T) {
+	server, _ := newTestServer(t, nil)
+	url := server.URL + "/users/octocat/events"
+
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	etag := res.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected an ETag header")
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("If-None-Match", etag)
+	res, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusNotModified {
+		t.Errorf("Expected 304 for a matching ETag, got %d", res.StatusCode)
+	}
+}
+
+func TestServerRateLimit(t *testing.T) {
+	server, api := newTestServer(t, func(fake *Server) {
+		fake.RateLimit = 2
+	})
+
+	for i, wantRemaining := range []string{"1", "0"} {
+		res, err := http.Get(server.URL + "/users/octocat/events")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		res.Body.Close()
+
+		if got := res.Header.Get("X-RateLimit-Remaining"); got != wantRemaining {
+			t.Errorf("Request %d: expected %s remaining, got %s", i+1, wantRemaining, got)
+		}
+		if res.Header.Get("X-RateLimit-Limit") != "2" || res.Header.Get("X-RateLimit-Reset") == "" {
+			t.Errorf("Request %d: missing rate limit headers: %v", i+1, res.Header)
+		}
+	}
+
+	res, err := http.Get(server.URL + "/users/octocat/events")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusForbidden || res.Header.Get("X-RateLimit-Remaining") != "0" {
+		t.Errorf("Expected 403 with 0 remaining once exhausted, got %d with %s", res.StatusCode, res.Header.Get("X-RateLimit-Remaining"))
+	}
+
+	if _, err := api.FetchUserEvents(context.Background(), "octocat", "1", "30"); err == nil {
+		t.Error("Expected the client to fail when rate limited")
+	}
+}
+
+func TestServerErrorInjection(t *testing.T) {
+	server, api := newTestServer(t, func(fake *Server) {
+		fake.FailEvery = 2
+		fake.FailStatus = http.StatusBadGateway
+	})
+
+	var statuses []int
+	for range 4 {
+		res, err := http.Get(server.URL + "/users/octocat/events")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		res.Body.Close()
+		statuses = append(statuses, res.StatusCode)
+	}
+
+	want := []int{200, 502, 200, 502}
+	if fmt.Sprint(statuses) != fmt.Sprint(want) {
+		t.Errorf("Expected statuses %v, got %v", want, statuses)
+	}
+
+	// The next two requests are the 5th (ok) and the 6th (failing) one
+	if _, err := api.FetchUserEvents(context.Background(), "octocat", "1", "30"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := api.FetchUserEvents(context.Background(), "octocat", "1", "30"); err == nil {
+		t.Error("Expected an injected error")
+	}
+
+	// With retries the client gets past every injected failure
+	api.Retry = client.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}
+	activities, err := api.FetchAllUserEvents(context.Background(), "octocat")
+	if err != nil {
+		t.Fatalf("Expected retries to recover from injected errors, got %v", err)
+	}
+	if len(activities) != 250 {
+		t.Errorf("Expected 250 activities, got %d", len(activities))
+	}
+}
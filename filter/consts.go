@@ -0,0 +1,20 @@
+package filter
+
+const (
+	ANY_TYPE = ""
+
+	BOT_FILTER_NONE    = ""
+	BOT_FILTER_EXCLUDE = "exclude"
+	BOT_FILTER_ONLY    = "only"
+
+	BOT_LOGIN_SUFFIX = "[bot]"
+	BOT_ACTOR_TYPE   = "Bot"
+)
+
+var DEFAULT_BOT_DENYLIST = []string{
+	"dependabot",
+	"dependabot-preview",
+	"renovate",
+	"renovate-bot",
+	"github-actions",
+}
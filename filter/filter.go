@@ -0,0 +1,63 @@
+// Package filter narrows lists of events down by type, bot activity and
+// full-text search.
+package filter
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+// ByType keeps the events whose type contains eventType, ignoring case. An
+// empty eventType keeps every event.
+func ByType(activities []events.Event, eventType string) []events.Event {
+	if eventType == ANY_TYPE {
+		return activities
+	}
+
+	var newEvents []events.Event
+
+	for _, event := range activities {
+		if strings.Contains(strings.ToLower(event.Type), strings.ToLower(eventType)) {
+			newEvents = append(newEvents, event)
+		}
+	}
+
+	return newEvents
+}
+
+// Bots hides bot events with BOT_FILTER_EXCLUDE or keeps only them with
+// BOT_FILTER_ONLY. Logins in denylist are treated as bots too.
+func Bots(activities []events.Event, mode string, denylist []string) []events.Event {
+	if mode == BOT_FILTER_NONE {
+		return activities
+	}
+
+	var newEvents []events.Event
+
+	for _, event := range activities {
+		if IsBot(event, denylist) == (mode == BOT_FILTER_ONLY) {
+			newEvents = append(newEvents, event)
+		}
+	}
+
+	return newEvents
+}
+
+// IsBot reports whether an event was created by a bot account, an actor of
+// type Bot or one of the logins in denylist.
+func IsBot(event events.Event, denylist []string) bool {
+	login := strings.ToLower(event.Actor.Login)
+
+	if strings.HasSuffix(login, BOT_LOGIN_SUFFIX) {
+		return true
+	}
+	if strings.EqualFold(event.Actor.Type, BOT_ACTOR_TYPE) {
+		return true
+	}
+
+	return slices.ContainsFunc(denylist, func(name string) bool {
+		return strings.EqualFold(strings.TrimSpace(name), login)
+	})
+}
@@ -0,0 +1,184 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+func TestByType(t *testing.T) {
+	// Sample test data
+	testEvents := []events.Event{
+		{Type: "PushEvent", Repo: struct {
+			Name string `json:"name"`
+		}{Name: "test-repo"}},
+		{Type: "PullRequestEvent", Repo: struct {
+			Name string `json:"name"`
+		}{Name: "test-repo"}},
+		{Type: "CreateEvent", Repo: struct {
+			Name string `json:"name"`
+		}{Name: "test-repo"}},
+		{Type: "WatchEvent", Repo: struct {
+			Name string `json:"name"`
+		}{Name: "test-repo"}},
+		{Type: "IssuesEvent", Repo: struct {
+			Name string `json:"name"`
+		}{Name: "test-repo"}},
+	}
+
+	tests := []struct {
+		name      string
+		events    []events.Event
+		eventType string
+		expected  []events.Event
+	}{
+		{
+			name:      "No filter - returns all events",
+			events:    testEvents,
+			eventType: ANY_TYPE,
+			expected:  testEvents,
+		},
+		{
+			name:      "Filter by PushEvent",
+			events:    testEvents,
+			eventType: "pushevent",
+			expected:  []events.Event{testEvents[0]},
+		},
+		{
+			name:      "Filter by PullRequestEvent",
+			events:    testEvents,
+			eventType: "pullrequestevent",
+			expected:  []events.Event{testEvents[1]},
+		},
+		{
+			name:      "Filter by partial match (case insensitive)",
+			events:    testEvents,
+			eventType: "push",
+			expected:  []events.Event{testEvents[0]},
+		},
+		{
+			name:      "Filter by partial match - request",
+			events:    testEvents,
+			eventType: "request",
+			expected:  []events.Event{testEvents[1]},
+		},
+		{
+			name:      "Filter with no matches",
+			events:    testEvents,
+			eventType: "nonexistentevent",
+			expected:  []events.Event{},
+		},
+		{
+			name:      "Empty events list",
+			events:    []events.Event{},
+			eventType: "pushevent",
+			expected:  []events.Event{},
+		},
+		{
+			name:      "Empty filter on empty events",
+			events:    []events.Event{},
+			eventType: ANY_TYPE,
+			expected:  []events.Event{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ByType(tt.events, tt.eventType)
+
+			// Handle nil vs empty slice comparison
+			if len(result) == 0 && len(tt.expected) == 0 {
+				return // Both are empty, test passes
+			}
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ByType() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestByTypeMultipleMatches(t *testing.T) {
+	// Test data with multiple events of the same type
+	testEvents := []events.Event{
+		{Type: "PushEvent", Repo: struct {
+			Name string `json:"name"`
+		}{Name: "repo1"}},
+		{Type: "PullRequestEvent", Repo: struct {
+			Name string `json:"name"`
+		}{Name: "repo2"}},
+		{Type: "PushEvent", Repo: struct {
+			Name string `json:"name"`
+		}{Name: "repo3"}},
+		{Type: "CreateEvent", Repo: struct {
+			Name string `json:"name"`
+		}{Name: "repo4"}},
+		{Type: "PushEvent", Repo: struct {
+			Name string `json:"name"`
+		}{Name: "repo5"}},
+	}
+
+	result := ByType(testEvents, "pushevent")
+	expected := []events.Event{testEvents[0], testEvents[2], testEvents[4]}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ByType() with multiple matches = %v, want %v", result, expected)
+	}
+
+	if len(result) != 3 {
+		t.Errorf("Expected 3 PushEvents, got %d", len(result))
+	}
+}
+
+func TestBots(t *testing.T) {
+	testEvents := []events.Event{
+		eventFromJSON(t, `{"type":"PushEvent","actor":{"login":"octocat"}}`),
+		eventFromJSON(t, `{"type":"PullRequestEvent","actor":{"login":"dependabot[bot]"}}`),
+		eventFromJSON(t, `{"type":"PushEvent","actor":{"login":"renovate-bot"}}`),
+		eventFromJSON(t, `{"type":"PushEvent","actor":{"login":"deploy-robot","type":"Bot"}}`),
+		eventFromJSON(t, `{"type":"PushEvent","actor":{"login":"ci-runner"}}`),
+	}
+
+	tests := []struct {
+		name     string
+		mode     string
+		denylist []string
+		expected []events.Event
+	}{
+		{
+			name:     "No bot filter - returns all events",
+			mode:     BOT_FILTER_NONE,
+			denylist: DEFAULT_BOT_DENYLIST,
+			expected: testEvents,
+		},
+		{
+			name:     "Exclude bots",
+			mode:     BOT_FILTER_EXCLUDE,
+			denylist: DEFAULT_BOT_DENYLIST,
+			expected: []events.Event{testEvents[0], testEvents[4]},
+		},
+		{
+			name:     "Only bots",
+			mode:     BOT_FILTER_ONLY,
+			denylist: DEFAULT_BOT_DENYLIST,
+			expected: []events.Event{testEvents[1], testEvents[2], testEvents[3]},
+		},
+		{
+			name:     "Custom denylist entry",
+			mode:     BOT_FILTER_EXCLUDE,
+			denylist: append(DEFAULT_BOT_DENYLIST, " CI-Runner"),
+			expected: []events.Event{testEvents[0]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Bots(testEvents, tt.mode, tt.denylist)
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Bots() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
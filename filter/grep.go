@@ -0,0 +1,63 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+// CompilePattern builds the search pattern used by Grep. The pattern is
+// matched literally unless useRegex is set.
+func CompilePattern(pattern string, useRegex, ignoreCase bool) (*regexp.Regexp, error) {
+	if !useRegex {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid grep pattern: %v", err)
+	}
+
+	return re, nil
+}
+
+// Grep keeps the events whose commit messages, titles, release names or
+// comment bodies match re. A nil re keeps every event.
+func Grep(activities []events.Event, re *regexp.Regexp) []events.Event {
+	if re == nil {
+		return activities
+	}
+
+	var newEvents []events.Event
+
+	for _, event := range activities {
+		for _, text := range SearchableTexts(event) {
+			if re.MatchString(text) {
+				newEvents = append(newEvents, event)
+				break
+			}
+		}
+	}
+
+	return newEvents
+}
+
+// SearchableTexts returns the texts of event that Grep matches against.
+func SearchableTexts(event events.Event) []string {
+	texts := []string{
+		event.Payload.PullReq.Title,
+		event.Payload.Issue.Title,
+		event.Payload.Release.Name,
+		event.Payload.Comment.Body,
+	}
+
+	for _, commit := range event.Payload.Commits {
+		texts = append(texts, commit.Message)
+	}
+
+	return texts
+}
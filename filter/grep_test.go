@@ -0,0 +1,115 @@
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+func eventFromJSON(t *testing.T, data string) events.Event {
+	t.Helper()
+
+	var event events.Event
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		t.Fatalf("Couldn't decode test event: %v", err)
+	}
+
+	return event
+}
+
+func TestCompilePattern(t *testing.T) {
+	tests := []struct {
+		name       string
+		pattern    string
+		useRegex   bool
+		ignoreCase bool
+		input      string
+		expected   bool
+		expectErr  bool
+	}{
+		{
+			name:     "Literal match",
+			pattern:  "JIRA-1234",
+			input:    "Fix JIRA-1234 crash",
+			expected: true,
+		},
+		{
+			name:     "Literal pattern escapes regex characters",
+			pattern:  "fix(api)",
+			input:    "fix(api): handle nil",
+			expected: true,
+		},
+		{
+			name:     "Literal match is case sensitive",
+			pattern:  "jira-1234",
+			input:    "Fix JIRA-1234 crash",
+			expected: false,
+		},
+		{
+			name:       "Ignore case",
+			pattern:    "jira-1234",
+			ignoreCase: true,
+			input:      "Fix JIRA-1234 crash",
+			expected:   true,
+		},
+		{
+			name:     "Regex match",
+			pattern:  `JIRA-\d+`,
+			useRegex: true,
+			input:    "Fix JIRA-77 crash",
+			expected: true,
+		},
+		{
+			name:      "Invalid regex",
+			pattern:   "JIRA-(",
+			useRegex:  true,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, err := CompilePattern(tt.pattern, tt.useRegex, tt.ignoreCase)
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected error for pattern %q", tt.pattern)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if re.MatchString(tt.input) != tt.expected {
+				t.Errorf("Expected match=%v for %q against %q", tt.expected, tt.pattern, tt.input)
+			}
+		})
+	}
+}
+
+func TestGrep(t *testing.T) {
+	activities := []events.Event{
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"a/b"},"payload":{"commits":[{"message":"Initial commit"},{"message":"Fix JIRA-1234"}]}}`),
+		eventFromJSON(t, `{"type":"PullRequestEvent","repo":{"name":"a/b"},"payload":{"pull_request":{"title":"JIRA-1234: add retries"}}}`),
+		eventFromJSON(t, `{"type":"IssuesEvent","repo":{"name":"a/b"},"payload":{"issue":{"title":"Crash on start"}}}`),
+		eventFromJSON(t, `{"type":"IssueCommentEvent","repo":{"name":"a/b"},"payload":{"issue":{"title":"Crash"},"comment":{"body":"Tracked in jira-1234"}}}`),
+		eventFromJSON(t, `{"type":"ReleaseEvent","repo":{"name":"a/b"},"payload":{"release":{"name":"v1.0 JIRA-1234"}}}`),
+		eventFromJSON(t, `{"type":"WatchEvent","repo":{"name":"JIRA-1234"}}`),
+	}
+
+	re, _ := CompilePattern("JIRA-1234", false, false)
+	result := Grep(activities, re)
+	if len(result) != 3 {
+		t.Errorf("Expected 3 case-sensitive matches, got %d", len(result))
+	}
+
+	re, _ = CompilePattern("JIRA-1234", false, true)
+	result = Grep(activities, re)
+	if len(result) != 4 {
+		t.Errorf("Expected 4 case-insensitive matches, got %d", len(result))
+	}
+
+	if len(Grep(activities, nil)) != len(activities) {
+		t.Error("Expected nil pattern to keep all events")
+	}
+}
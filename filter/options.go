@@ -0,0 +1,53 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+// Options selects the filters Apply runs, in order: event type, bots and
+// full-text search. The zero value keeps every event.
+type Options struct {
+	Type        string
+	Bots        string
+	BotDenylist []string
+	Grep        *regexp.Regexp
+	GrepPattern string
+}
+
+// NoResultError is returned by Apply when a filter removed every event.
+type NoResultError struct {
+	Filter string
+}
+
+func (e NoResultError) Error() string {
+	return fmt.Sprintf("no result for %s", e.Filter)
+}
+
+// Apply runs every filter of opts and fails with a NoResultError naming the
+// first filter that left no events.
+func Apply(activities []events.Event, opts Options) ([]events.Event, error) {
+	activities = ByType(activities, opts.Type)
+	if len(activities) < 1 && opts.Type != ANY_TYPE {
+		return activities, NoResultError{Filter: fmt.Sprintf("'%s' filter", opts.Type)}
+	}
+
+	activities = Bots(activities, opts.Bots, opts.BotDenylist)
+	if len(activities) < 1 && opts.Bots != BOT_FILTER_NONE {
+		// Named after the flag, which is how users know the bot filters
+		flag := "--no-bots"
+		if opts.Bots == BOT_FILTER_ONLY {
+			flag = "--only-bots"
+		}
+		return activities, NoResultError{Filter: flag + " filter"}
+	}
+
+	activities = Grep(activities, opts.Grep)
+	if len(activities) < 1 && opts.Grep != nil {
+		return activities, NoResultError{Filter: fmt.Sprintf("'%s' search", opts.GrepPattern)}
+	}
+
+	return activities, nil
+}
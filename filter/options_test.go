@@ -0,0 +1,48 @@
+package filter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+func TestApply(t *testing.T) {
+	activities := []events.Event{
+		eventFromJSON(t, `{"type":"PushEvent","actor":{"login":"octocat"},"payload":{"commits":[{"message":"fix bug"}]}}`),
+		eventFromJSON(t, `{"type":"PullRequestEvent","actor":{"login":"dependabot[bot]"},"payload":{"pull_request":{"title":"Bump deps"}}}`),
+	}
+
+	if result, err := Apply(activities, Options{}); err != nil || len(result) != 2 {
+		t.Errorf("Expected zero options to keep 2 events, got %d and %v", len(result), err)
+	}
+
+	re, _ := CompilePattern("fix", false, false)
+	result, err := Apply(activities, Options{Bots: BOT_FILTER_EXCLUDE, Grep: re, GrepPattern: "fix"})
+	if err != nil || len(result) != 1 {
+		t.Errorf("Expected 1 event and no error, got %d and %v", len(result), err)
+	}
+
+	var noResult NoResultError
+
+	_, err = Apply(activities, Options{Type: "Release"})
+	if !errors.As(err, &noResult) || noResult.Filter != "'Release' filter" {
+		t.Errorf("Expected no result error for filter, got %v", err)
+	}
+
+	_, err = Apply(activities[:1], Options{Bots: BOT_FILTER_ONLY})
+	if !errors.As(err, &noResult) || noResult.Filter != "--only-bots filter" {
+		t.Errorf("Expected no result error for bot filter, got %v", err)
+	}
+
+	_, err = Apply(activities[1:], Options{Bots: BOT_FILTER_EXCLUDE})
+	if !errors.As(err, &noResult) || noResult.Error() != "no result for --no-bots filter" {
+		t.Errorf("Expected no result error naming --no-bots, got %v", err)
+	}
+
+	re, _ = CompilePattern("missing", false, false)
+	_, err = Apply(activities, Options{Grep: re, GrepPattern: "missing"})
+	if !errors.As(err, &noResult) || noResult.Filter != "'missing' search" {
+		t.Errorf("Expected no result error for search, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package render
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+func collapsedActivityString(userActivity events.Event) string {
+	run := userActivity.Collapsed
+	span := collapsedSpan(run)
+
+	switch userActivity.Type {
+	case events.PUSH_EVENT:
+		commits := 0
+		for _, event := range run {
+			commits += events.PushCommitCount(event)
+		}
+
+		return fmt.Sprintf(
+			"Pushed %d commits to %s in %d pushes%s",
+			commits,
+			userActivity.Repo.Name,
+			len(run),
+			span,
+		)
+	case events.ISSUES_COMMENT_EVENT:
+		return fmt.Sprintf(
+			"Commented %d times at %s%s",
+			len(run),
+			userActivity.Repo.Name,
+			span,
+		)
+	default:
+		return fmt.Sprintf(
+			"%d %s events at %s%s",
+			len(run),
+			userActivity.Type,
+			userActivity.Repo.Name,
+			span,
+		)
+	}
+}
+
+func collapsedSpan(run []events.Event) string {
+	first, last := run[len(run)-1].CreatedAt, run[0].CreatedAt
+	if first.IsZero() || last.IsZero() {
+		return ""
+	}
+
+	span := last.Sub(first)
+	if span < 0 {
+		span = -span
+	}
+
+	switch {
+	case span < time.Minute:
+		return ""
+	case span < time.Hour:
+		return fmt.Sprintf(" over %dm", int(span.Minutes()))
+	case span < 24*time.Hour:
+		return fmt.Sprintf(" over %dh", int(span.Hours()))
+	default:
+		return fmt.Sprintf(" over %dd", int(span.Hours()/24))
+	}
+}
@@ -0,0 +1,49 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+func TestCollapseEvents(t *testing.T) {
+	activities := []events.Event{
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"x/y"},"payload":{"size":2},"created_at":"2025-03-02T15:00:00Z"}`),
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"x/y"},"payload":{"size":30},"created_at":"2025-03-02T13:30:00Z"}`),
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"x/y"},"payload":{"size":10},"created_at":"2025-03-02T12:00:00Z"}`),
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"x/z"},"payload":{"size":1},"created_at":"2025-03-02T11:00:00Z"}`),
+		eventFromJSON(t, `{"type":"IssueCommentEvent","repo":{"name":"x/z"},"created_at":"2025-03-02T10:50:00Z"}`),
+		eventFromJSON(t, `{"type":"IssueCommentEvent","repo":{"name":"x/z"},"created_at":"2025-03-02T10:30:00Z"}`),
+		eventFromJSON(t, `{"type":"WatchEvent","repo":{"name":"x/z"}}`),
+		eventFromJSON(t, `{"type":"WatchEvent","repo":{"name":"x/z"}}`),
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"x/y"},"payload":{"size":3}}`),
+	}
+
+	result := events.Collapse(activities)
+
+	expected := []string{
+		"Pushed 42 commits to x/y in 3 pushes over 3h",
+		"Pushed 1 commits to x/z",
+		"Commented 2 times at x/z over 20m",
+		"2 WatchEvent events at x/z",
+		"Pushed 3 commits to x/y",
+	}
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d events after collapsing, got %d", len(expected), len(result))
+	}
+
+	for i, event := range result {
+		line, err := ActivityString(event)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if line != expected[i] {
+			t.Errorf("Expected line %d to be %q, got %q", i, expected[i], line)
+		}
+	}
+
+	if len(result[0].Collapsed) != 3 || len(result[1].Collapsed) != 0 {
+		t.Errorf("Unexpected collapsed runs: %d and %d", len(result[0].Collapsed), len(result[1].Collapsed))
+	}
+}
@@ -0,0 +1,31 @@
+package render
+
+const (
+	OUTPUT_TEXT = "text"
+	OUTPUT_JSON = "json"
+)
+
+var (
+	OUTPUT_FORMATS   = []string{OUTPUT_TEXT, OUTPUT_JSON}
+	GROUP_BY_OPTIONS = []string{GROUP_BY_REPO, GROUP_BY_DAY, GROUP_BY_TYPE}
+)
+
+const (
+	GROUP_BY_NONE = ""
+	GROUP_BY_REPO = "repo"
+	GROUP_BY_DAY  = "day"
+	GROUP_BY_TYPE = "type"
+
+	DAY_FORMAT    = "2006-01-02"
+	UNKNOWN_GROUP = "unknown"
+)
+
+const (
+	BRANCH_REF_PREFIX = "refs/heads/"
+	SHORT_SHA_LENGTH  = 7
+)
+
+const (
+	HIGHLIGHT_START = "\033[1;33m"
+	HIGHLIGHT_END   = "\033[0m"
+)
@@ -0,0 +1,94 @@
+package render
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+// Group is a run of events sharing the same key.
+type Group struct {
+	Key    string
+	Events []events.Event
+}
+
+// Count is the number of events in the group, counting every event a
+// collapsed one stands for.
+func (group Group) Count() int {
+	count := 0
+	for _, event := range group.Events {
+		count += len(events.ExpandCollapsed(event))
+	}
+	return count
+}
+
+// GroupEvents buckets activities by repo, day or type, keeping the order in
+// which each key first appears. Days are calendar days in location, or in
+// the local time zone when location is nil.
+func GroupEvents(activities []events.Event, groupBy string, location *time.Location) []Group {
+	if location == nil {
+		location = time.Local
+	}
+
+	var groups []Group
+	index := map[string]int{}
+
+	for _, event := range activities {
+		key := groupKey(event, groupBy, location)
+
+		idx, ok := index[key]
+		if !ok {
+			idx = len(groups)
+			index[key] = idx
+			groups = append(groups, Group{Key: key})
+		}
+
+		groups[idx].Events = append(groups[idx].Events, event)
+	}
+
+	return groups
+}
+
+// groups splits activities as opts.GroupBy says, into a single group when
+// they aren't grouped, and collapses every group on its own so a run of
+// similar events never spans two groups.
+func (opts Options) groups(activities []events.Event) []Group {
+	groups := []Group{{Events: activities}}
+	if opts.GroupBy != GROUP_BY_NONE {
+		groups = GroupEvents(activities, opts.GroupBy, opts.Location)
+	}
+
+	if opts.Collapse {
+		for i := range groups {
+			groups[i].Events = events.Collapse(groups[i].Events)
+		}
+	}
+	return groups
+}
+
+func groupKey(event events.Event, groupBy string, location *time.Location) string {
+	switch groupBy {
+	case GROUP_BY_REPO:
+		return event.Repo.Name
+	case GROUP_BY_TYPE:
+		return event.Type
+	case GROUP_BY_DAY:
+		if event.CreatedAt.IsZero() {
+			return UNKNOWN_GROUP
+		}
+		return event.CreatedAt.In(location).Format(DAY_FORMAT)
+	default:
+		return ""
+	}
+}
+
+// ValidateGroupBy checks that groupBy is one of the GROUP_BY_* constants.
+func ValidateGroupBy(groupBy string) error {
+	switch groupBy {
+	case GROUP_BY_NONE, GROUP_BY_REPO, GROUP_BY_DAY, GROUP_BY_TYPE:
+		return nil
+	default:
+		return fmt.Errorf("unknown group '%s', expected one of: repo, day, type", groupBy)
+	}
+}
@@ -0,0 +1,205 @@
+package render
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+func TestGroupEvents(t *testing.T) {
+	day1 := time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC)
+	day2 := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	activities := []events.Event{
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"a/one"},"created_at":"2025-03-02T12:00:00Z"}`),
+		eventFromJSON(t, `{"type":"WatchEvent","repo":{"name":"a/two"},"created_at":"2025-03-02T11:00:00Z"}`),
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"a/one"},"created_at":"2025-03-01T12:00:00Z"}`),
+		eventFromJSON(t, `{"type":"IssuesEvent","repo":{"name":"a/two"}}`),
+	}
+
+	tests := []struct {
+		name         string
+		groupBy      string
+		expectedKeys []string
+		expectedLens []int
+	}{
+		{
+			name:         "Group by repo",
+			groupBy:      GROUP_BY_REPO,
+			expectedKeys: []string{"a/one", "a/two"},
+			expectedLens: []int{2, 2},
+		},
+		{
+			name:         "Group by type",
+			groupBy:      GROUP_BY_TYPE,
+			expectedKeys: []string{events.PUSH_EVENT, events.WATCH_EVENT, events.ISSUES_EVENT},
+			expectedLens: []int{2, 1, 1},
+		},
+		{
+			name:    "Group by day",
+			groupBy: GROUP_BY_DAY,
+			expectedKeys: []string{
+				day1.Local().Format(DAY_FORMAT),
+				day2.Local().Format(DAY_FORMAT),
+				UNKNOWN_GROUP,
+			},
+			expectedLens: []int{2, 1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := GroupEvents(activities, tt.groupBy, nil)
+
+			if len(groups) != len(tt.expectedKeys) {
+				t.Fatalf("Expected %d groups, got %d", len(tt.expectedKeys), len(groups))
+			}
+			for i, group := range groups {
+				if group.Key != tt.expectedKeys[i] {
+					t.Errorf("Expected group %d key %s, got %s", i, tt.expectedKeys[i], group.Key)
+				}
+				if len(group.Events) != tt.expectedLens[i] {
+					t.Errorf("Expected group %s to have %d events, got %d", group.Key, tt.expectedLens[i], len(group.Events))
+				}
+			}
+		})
+	}
+
+	// Events keep their original chronological order within a group
+	repoGroups := GroupEvents(activities, GROUP_BY_REPO, nil)
+	if !repoGroups[0].Events[0].CreatedAt.After(repoGroups[0].Events[1].CreatedAt) {
+		t.Error("Expected events within a group to keep their original order")
+	}
+}
+
+func TestValidateGroupBy(t *testing.T) {
+	for _, groupBy := range []string{GROUP_BY_NONE, GROUP_BY_REPO, GROUP_BY_DAY, GROUP_BY_TYPE} {
+		if err := ValidateGroupBy(groupBy); err != nil {
+			t.Errorf("Unexpected error for group %q: %v", groupBy, err)
+		}
+	}
+
+	if err := ValidateGroupBy("week"); err == nil {
+		t.Error("Expected error for unknown group")
+	}
+}
+
+func TestPrinterGrouped(t *testing.T) {
+	activities := []events.Event{
+		eventFromJSON(t, `{"type":"WatchEvent","repo":{"name":"a/one"},"payload":{"action":"started"}}`),
+		eventFromJSON(t, `{"type":"PublicEvent","repo":{"name":"a/two"}}`),
+		eventFromJSON(t, `{"type":"PublicEvent","repo":{"name":"a/one"}}`),
+	}
+
+	output, err := printToString(activities, Options{GroupBy: GROUP_BY_REPO})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "a/one (2)\n" +
+		"  - Started watching a/one\n" +
+		"  - Repo a/one is now public\n" +
+		"a/two (1)\n" +
+		"  - Repo a/two is now public\n"
+	if output != expected {
+		t.Errorf("Expected output %q, got %q", expected, output)
+	}
+}
+
+func TestGroupedCollapsed(t *testing.T) {
+	activities := events.Collapse([]events.Event{
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"a/one"},"actor":{"login":"octocat"},"payload":{"size":1}}`),
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"a/one"},"actor":{"login":"octocat"},"payload":{"size":2}}`),
+	})
+	if len(activities) != 1 {
+		t.Fatalf("Expected the pushes to collapse into 1 event, got %d", len(activities))
+	}
+
+	groups := GroupEvents(activities, GROUP_BY_REPO, nil)
+	if len(groups) != 1 || groups[0].Count() != 2 {
+		t.Fatalf("Expected 1 group counting 2 events, got %+v", groups)
+	}
+
+	output, err := printToString(activities, Options{GroupBy: GROUP_BY_REPO})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(output, "a/one (2)\n") {
+		t.Errorf("Expected the header to count both pushes, got %q", output)
+	}
+
+	output, err = printToString(activities, Options{Output: OUTPUT_JSON, GroupBy: GROUP_BY_REPO})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded []jsonGroup
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+	}
+	if len(decoded) != 1 || decoded[0].Count != 2 || len(decoded[0].Events) != 1 || decoded[0].Events[0].Collapsed != 2 {
+		t.Errorf("Expected count 2 over 1 collapsed event, got %+v", decoded)
+	}
+}
+
+func TestPrinterGroupedByDayCollapse(t *testing.T) {
+	// A run of pushes crossing midnight is collapsed within each day only
+	activities := []events.Event{
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"a/b"},"actor":{"login":"octocat"},"payload":{"size":1},"created_at":"2026-10-18T12:00:00Z"}`),
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"a/b"},"actor":{"login":"octocat"},"payload":{"size":1},"created_at":"2026-10-18T11:00:00Z"}`),
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"a/b"},"actor":{"login":"octocat"},"payload":{"size":1},"created_at":"2026-10-17T12:00:00Z"}`),
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"a/b"},"actor":{"login":"octocat"},"payload":{"size":1},"created_at":"2026-10-16T12:00:00Z"}`),
+	}
+
+	output, err := printToString(activities, Options{Output: OUTPUT_JSON, GroupBy: GROUP_BY_DAY, Collapse: true, Location: time.UTC})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded []jsonGroup
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+	}
+
+	expectedCounts := []int{2, 1, 1}
+	if len(decoded) != len(expectedCounts) {
+		t.Fatalf("Expected a group per day, got %+v", decoded)
+	}
+	for i, group := range decoded {
+		if group.Count != expectedCounts[i] || len(group.Events) != 1 {
+			t.Errorf("Expected group %s to count %d events on one line, got %+v", group.Key, expectedCounts[i], group)
+		}
+	}
+	if decoded[0].Events[0].Collapsed != 2 {
+		t.Errorf("Expected the two pushes of the same day collapsed, got %+v", decoded[0].Events[0])
+	}
+
+	output, err = printToString(activities, Options{GroupBy: GROUP_BY_DAY, Collapse: true, Location: time.UTC})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Count(output, "(1)\n") != 2 || !strings.Contains(output, "(2)\n") {
+		t.Errorf("Expected the day headers to count their own events, got %q", output)
+	}
+}
+
+func TestGroupEventsLocation(t *testing.T) {
+	activities := []events.Event{
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"a/b"},"created_at":"2026-10-18T23:30:00Z"}`),
+	}
+
+	if groups := GroupEvents(activities, GROUP_BY_DAY, time.UTC); groups[0].Key != "2026-10-18" {
+		t.Errorf("Expected the UTC day, got %s", groups[0].Key)
+	}
+
+	// Already the next morning in Tokyo
+	tokyo := time.FixedZone("JST", 9*3600)
+	output, err := printToString(activities, Options{GroupBy: GROUP_BY_DAY, Location: tokyo})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(output, "2026-10-19 (1)\n") {
+		t.Errorf("Expected the event grouped under the Tokyo day, got %q", output)
+	}
+}
@@ -0,0 +1,62 @@
+package render
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+	"github.com/dmitriy-zverev/github-activity/filter"
+)
+
+// highlightMatches marks the matches of re in text, but only inside the
+// parts of text that come from fields. Everything else, such as a repository
+// name, stays plain because --grep doesn't search it either.
+func highlightMatches(text string, fields []string, re *regexp.Regexp) string {
+	if re == nil {
+		return text
+	}
+
+	var spans [][2]int
+	for _, field := range fields {
+		for _, line := range strings.Split(field, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || !re.MatchString(line) {
+				continue
+			}
+			if start := strings.Index(text, line); start >= 0 {
+				spans = append(spans, [2]int{start, start + len(line)})
+			}
+		}
+	}
+	slices.SortFunc(spans, func(a, b [2]int) int { return a[0] - b[0] })
+
+	var highlighted strings.Builder
+	end := 0
+	for _, span := range spans {
+		if span[0] < end {
+			continue
+		}
+		highlighted.WriteString(text[end:span[0]])
+		highlighted.WriteString(re.ReplaceAllStringFunc(text[span[0]:span[1]], func(match string) string {
+			if match == "" {
+				return match
+			}
+			return HIGHLIGHT_START + match + HIGHLIGHT_END
+		}))
+		end = span[1]
+	}
+	highlighted.WriteString(text[end:])
+
+	return highlighted.String()
+}
+
+// searchedTexts returns what --grep searches in userActivity and in every
+// event collapsed into it.
+func searchedTexts(userActivity events.Event) []string {
+	var texts []string
+	for _, activity := range events.ExpandCollapsed(userActivity) {
+		texts = append(texts, filter.SearchableTexts(activity)...)
+	}
+	return texts
+}
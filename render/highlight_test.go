@@ -0,0 +1,53 @@
+package render
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+func TestHighlightMatches(t *testing.T) {
+	re, _ := regexp.Compile("retries")
+
+	result := highlightMatches("Pull request 'add retries' opened at a/b", []string{"add retries"}, re)
+	expected := "Pull request 'add " + HIGHLIGHT_START + "retries" + HIGHLIGHT_END + "' opened at a/b"
+	if result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+
+	// The repository isn't searched by --grep, so it isn't highlighted either
+	result = highlightMatches("Pull request 'add retries' opened at acme/retries", []string{"add retries"}, re)
+	expected = "Pull request 'add " + HIGHLIGHT_START + "retries" + HIGHLIGHT_END + "' opened at acme/retries"
+	if result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+
+	result = highlightMatches("Pushed 2 commits to acme/retries", []string{"fix bug", "add retries\n\nlong body"}, re)
+	if result != "Pushed 2 commits to acme/retries" {
+		t.Errorf("Expected commit messages outside the line to leave it plain, got %q", result)
+	}
+
+	if highlightMatches("unchanged", []string{"unchanged"}, nil) != "unchanged" {
+		t.Error("Expected nil pattern to leave text unchanged")
+	}
+}
+
+func TestPrinterHighlight(t *testing.T) {
+	activities := []events.Event{
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"acme/fix"},"payload":{"ref":"refs/heads/fix","commits":[{"sha":"0123456789","message":"fix retries\n\nmore"}]}}`),
+	}
+	re, _ := regexp.Compile("fix")
+
+	output, err := printToString(activities, Options{Highlight: re, Verbose: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "  - Pushed 1 commits to acme/fix\n" +
+		"      Branch: fix\n" +
+		"      0123456 " + HIGHLIGHT_START + "fix" + HIGHLIGHT_END + " retries\n"
+	if output != expected {
+		t.Errorf("Expected only the commit message highlighted:\n%q\ngot\n%q", expected, output)
+	}
+}
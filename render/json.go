@@ -0,0 +1,102 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+type jsonEvent struct {
+	Host      string    `json:"host,omitempty"`
+	Type      string    `json:"type"`
+	Repo      string    `json:"repo"`
+	Actor     string    `json:"actor,omitempty"`
+	ActorName string    `json:"actor_name,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Summary   string    `json:"summary"`
+	Collapsed int       `json:"collapsed,omitempty"`
+	Details   []string  `json:"details,omitempty"`
+}
+
+type jsonGroup struct {
+	Key    string      `json:"key"`
+	Count  int         `json:"count"`
+	Events []jsonEvent `json:"events"`
+}
+
+func printJSON(w io.Writer, userActivities []events.Event, opts Options) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if opts.GroupBy == GROUP_BY_NONE {
+		jsonEvents, err := toJSONEvents(opts.groups(userActivities)[0].Events, opts)
+		if err != nil {
+			return err
+		}
+		return encoder.Encode(jsonEvents)
+	}
+
+	var groups []jsonGroup
+	for _, group := range opts.groups(userActivities) {
+		jsonEvents, err := toJSONEvents(group.Events, opts)
+		if err != nil {
+			return err
+		}
+
+		groups = append(groups, jsonGroup{
+			Key:    group.Key,
+			Count:  group.Count(),
+			Events: jsonEvents,
+		})
+	}
+
+	return encoder.Encode(groups)
+}
+
+func toJSONEvents(userActivities []events.Event, opts Options) ([]jsonEvent, error) {
+	jsonEvents := make([]jsonEvent, 0, len(userActivities))
+
+	for _, activity := range userActivities {
+		summary, err := ActivityString(activity)
+		if err != nil {
+			return nil, err
+		}
+
+		event := jsonEvent{
+			Host:      opts.Host,
+			Type:      activity.Type,
+			Repo:      activity.Repo.Name,
+			Actor:     activity.Actor.Login,
+			CreatedAt: activity.CreatedAt,
+			Summary:   summary,
+		}
+		if opts.ActorNames != nil {
+			event.ActorName = opts.actorName(activity)
+		}
+		if len(activity.Collapsed) > 1 {
+			event.Collapsed = len(activity.Collapsed)
+		}
+		if opts.Verbose && activity.Type == events.PUSH_EVENT {
+			for _, push := range events.ExpandCollapsed(activity) {
+				event.Details = append(event.Details, pushDetails(push)...)
+			}
+		}
+
+		jsonEvents = append(jsonEvents, event)
+	}
+
+	return jsonEvents, nil
+}
+
+// ValidateOutputFormat checks that format is one Print understands.
+func ValidateOutputFormat(format string) error {
+	switch format {
+	case OUTPUT_TEXT, OUTPUT_JSON:
+		return nil
+	default:
+		return fmt.Errorf("unknown output format '%s', expected one of: text, json", format)
+	}
+}
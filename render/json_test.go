@@ -0,0 +1,91 @@
+package render
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+func TestJSONPrinter(t *testing.T) {
+	activities := []events.Event{
+		eventFromJSON(t, `{"type":"WatchEvent","actor":{"login":"octocat"},"repo":{"name":"a/one"},"payload":{"action":"started"},"created_at":"2025-03-02T12:00:00Z"}`),
+		eventFromJSON(t, `{"type":"PublicEvent","repo":{"name":"a/two"},"created_at":"2025-03-01T12:00:00Z"}`),
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"a/one"},"payload":{"ref":"refs/heads/main","commits":[{"sha":"0123456789","message":"Add feature"}]}}`),
+	}
+
+	t.Run("Flat", func(t *testing.T) {
+		output, err := printToString(activities, Options{Output: OUTPUT_JSON})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var decoded []jsonEvent
+		if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+			t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+		}
+		if len(decoded) != 3 {
+			t.Fatalf("Expected 3 events, got %d", len(decoded))
+		}
+		if decoded[0].Summary != "Started watching a/one" || decoded[0].Actor != "octocat" {
+			t.Errorf("Unexpected first event: %+v", decoded[0])
+		}
+		if decoded[2].Details != nil {
+			t.Errorf("Expected no push details without verbose, got %v", decoded[2].Details)
+		}
+		if strings.Contains(output, `"host"`) {
+			t.Errorf("Expected no host without Options.Host:\n%s", output)
+		}
+	})
+
+	t.Run("Host", func(t *testing.T) {
+		output, err := printToString(activities, Options{Output: OUTPUT_JSON, Host: "ghe.example.com"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var decoded []jsonEvent
+		if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+			t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+		}
+		for _, event := range decoded {
+			if event.Host != "ghe.example.com" {
+				t.Errorf("Expected every event labeled with its host, got %+v", event)
+			}
+		}
+	})
+
+	t.Run("Grouped and verbose", func(t *testing.T) {
+		output, err := printToString(activities, Options{Output: OUTPUT_JSON, GroupBy: GROUP_BY_REPO, Verbose: true})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var decoded []jsonGroup
+		if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+			t.Fatalf("Output is not valid JSON: %v\n%s", err, output)
+		}
+		if len(decoded) != 2 {
+			t.Fatalf("Expected 2 groups, got %d", len(decoded))
+		}
+		if decoded[0].Key != "a/one" || decoded[0].Count != 2 || len(decoded[0].Events) != 2 {
+			t.Errorf("Unexpected first group: %+v", decoded[0])
+		}
+		if len(decoded[0].Events[1].Details) != 2 {
+			t.Errorf("Expected verbose push details, got %v", decoded[0].Events[1].Details)
+		}
+	})
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	if err := ValidateOutputFormat(OUTPUT_TEXT); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := ValidateOutputFormat(OUTPUT_JSON); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := ValidateOutputFormat("yaml"); err == nil {
+		t.Error("Expected error for unknown output format")
+	}
+}
@@ -0,0 +1,269 @@
+// Package render turns events into the text and JSON output of the
+// github-activity command line.
+package render
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+	"github.com/dmitriy-zverev/github-activity/filter"
+)
+
+// Options controls how Print lays out events.
+type Options struct {
+	// Highlight marks its matches in text output
+	Highlight *regexp.Regexp
+	// Verbose lists the commits of every push
+	Verbose bool
+	// Output is OUTPUT_TEXT or OUTPUT_JSON
+	Output string
+	// GroupBy is one of the GROUP_BY_* constants
+	GroupBy string
+	// ActorNames maps lowercase logins to display names and prefixes every
+	// event with its actor when set
+	ActorNames map[string]string
+	// Host labels every JSON event with the host it came from when set
+	Host string
+	// Collapse merges runs of similar events within each group
+	Collapse bool
+	// Location is the time zone that decides the day of an event when
+	// grouping by day, the local one when nil
+	Location *time.Location
+}
+
+// Print writes userActivities to w as described by opts.
+func Print(w io.Writer, userActivities []events.Event, opts Options) error {
+	if len(userActivities) < 1 {
+		return errors.New("found no user activity")
+	}
+
+	if opts.Output == OUTPUT_JSON {
+		return printJSON(w, userActivities, opts)
+	}
+
+	if opts.GroupBy == GROUP_BY_NONE {
+		return printActivities(w, opts.groups(userActivities)[0].Events, opts)
+	}
+
+	for _, group := range opts.groups(userActivities) {
+		fmt.Fprintf(w, "%s (%d)\n", group.Key, group.Count())
+		if err := printActivities(w, group.Events, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printActivities(w io.Writer, userActivities []events.Event, opts Options) error {
+	for _, activity := range userActivities {
+		userActivityString, err := ActivityString(activity)
+		if err != nil {
+			return err
+		}
+		if opts.ActorNames != nil {
+			userActivityString = fmt.Sprintf("%s: %s", opts.actorName(activity), userActivityString)
+		}
+		fmt.Fprintf(w, "  - %s\n", highlightMatches(userActivityString, searchedTexts(activity), opts.Highlight))
+
+		if opts.Verbose && activity.Type == events.PUSH_EVENT {
+			for _, push := range events.ExpandCollapsed(activity) {
+				for _, line := range pushDetails(push) {
+					fmt.Fprintf(w, "      %s\n", highlightMatches(line, filter.SearchableTexts(push), opts.Highlight))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (opts Options) actorName(userActivity events.Event) string {
+	if name, ok := opts.ActorNames[strings.ToLower(userActivity.Actor.Login)]; ok {
+		return name
+	}
+	return userActivity.Actor.Login
+}
+
+// ActivityString describes a single event, or the run of events collapsed
+// into it, in one line.
+func ActivityString(userActivity events.Event) (string, error) {
+	if len(userActivity.Collapsed) > 1 {
+		return collapsedActivityString(userActivity), nil
+	}
+
+	switch userActivity.Type {
+	case events.PUSH_EVENT:
+		return fmt.Sprintf(
+			"Pushed %d commits to %s",
+			events.PushCommitCount(userActivity),
+			userActivity.Repo.Name,
+		), nil
+	case events.CREATE_EVENT:
+		if userActivity.Payload.RefType == "repository" {
+			return fmt.Sprintf(
+				"Created %s at %s",
+				userActivity.Payload.RefType,
+				userActivity.Repo.Name,
+			), nil
+		}
+
+		return fmt.Sprintf(
+			"Created %s '%s' at %s",
+			userActivity.Payload.RefType,
+			userActivity.Payload.Ref,
+			userActivity.Repo.Name,
+		), nil
+	case events.WATCH_EVENT:
+		if userActivity.Payload.Action == "started" {
+			return fmt.Sprintf(
+				"Started watching %s",
+				userActivity.Repo.Name,
+			), nil
+		}
+
+		return fmt.Sprintf(
+			"Ended watching %s",
+			userActivity.Repo.Name,
+		), nil
+	case events.DELETE_EVENT:
+		return fmt.Sprintf(
+			"Deleted %s '%s' at %s",
+			userActivity.Payload.RefType,
+			userActivity.Payload.Ref,
+			userActivity.Repo.Name,
+		), nil
+	case events.FORK_EVENT:
+		return fmt.Sprintf(
+			"Forked %s to %s",
+			userActivity.Repo.Name,
+			userActivity.Payload.Forkee.FullName,
+		), nil
+	case events.ISSUES_EVENT:
+		return fmt.Sprintf(
+			"Issue '%s' %s at %s",
+			userActivity.Payload.Issue.Title,
+			userActivity.Payload.Action,
+			userActivity.Repo.Name,
+		), nil
+	case events.ISSUES_COMMENT_EVENT:
+		return fmt.Sprintf(
+			"Commented at issue '%s' at %s",
+			userActivity.Payload.Issue.Title,
+			userActivity.Repo.Name,
+		), nil
+	case events.PULL_REQUEST_EVENT:
+		return fmt.Sprintf(
+			"Pull request '%s' %s at %s",
+			userActivity.Payload.PullReq.Title,
+			userActivity.Payload.Action,
+			userActivity.Repo.Name,
+		), nil
+	case events.PUBLIC_EVENT:
+		return fmt.Sprintf(
+			"Repo %s is now public",
+			userActivity.Repo.Name,
+		), nil
+	case events.MEMBER_EVENT:
+		return fmt.Sprintf(
+			"Added %s to %s",
+			userActivity.Payload.Member.Login,
+			userActivity.Repo.Name,
+		), nil
+	case events.RELEASE_EVENT:
+		return fmt.Sprintf(
+			"Released '%s' at %s",
+			userActivity.Payload.Release.Name,
+			userActivity.Repo.Name,
+		), nil
+	default:
+		return fmt.Sprintf(
+			"%s to %s",
+			userActivity.Type,
+			userActivity.Repo.Name,
+		), nil
+	}
+}
+
+// Details describes userActivity beyond its one-line summary, with every
+// event collapsed into it: commits of pushes, titles of pull requests and
+// issues, comment bodies, releases, forks and members.
+func Details(userActivity events.Event) []string {
+	var lines []string
+
+	for _, activity := range events.ExpandCollapsed(userActivity) {
+		payload := activity.Payload
+
+		switch activity.Type {
+		case events.PUSH_EVENT:
+			lines = append(lines, pushDetails(activity)...)
+		case events.PULL_REQUEST_EVENT:
+			line := fmt.Sprintf("Pull request #%d: %s (%s)", payload.Number, payload.PullReq.Title, payload.Action)
+			if payload.PullReq.Merged {
+				line += ", merged"
+			}
+			lines = append(lines, line)
+		case events.ISSUES_EVENT:
+			lines = append(lines, fmt.Sprintf("Issue #%d: %s (%s)", payload.Issue.Number, payload.Issue.Title, payload.Action))
+		case events.ISSUES_COMMENT_EVENT:
+			lines = append(lines, fmt.Sprintf("Issue #%d: %s", payload.Issue.Number, payload.Issue.Title))
+			lines = append(lines, strings.Split(strings.TrimSpace(payload.Comment.Body), "\n")...)
+		case events.RELEASE_EVENT:
+			lines = append(lines, fmt.Sprintf("Release: %s (%s)", payload.Release.Name, payload.Release.TagName))
+		case events.FORK_EVENT:
+			lines = append(lines, fmt.Sprintf("Fork: %s", payload.Forkee.FullName))
+		case events.MEMBER_EVENT:
+			lines = append(lines, fmt.Sprintf("Member: %s (%s)", payload.Member.Login, payload.Action))
+		case events.CREATE_EVENT, events.DELETE_EVENT:
+			if payload.Ref != "" && payload.RefType != "" {
+				lines = append(lines, fmt.Sprintf("%s: %s", strings.ToUpper(payload.RefType[:1])+payload.RefType[1:], payload.Ref))
+			}
+		}
+	}
+
+	return lines
+}
+
+func pushDetails(userActivity events.Event) []string {
+	var lines []string
+
+	if branch := strings.TrimPrefix(userActivity.Payload.Ref, BRANCH_REF_PREFIX); branch != "" {
+		lines = append(lines, fmt.Sprintf("Branch: %s", branch))
+	}
+
+	for _, commit := range userActivity.Payload.Commits {
+		line := fmt.Sprintf(
+			"%s %s",
+			shortSha(commit.Sha),
+			firstLine(commit.Message),
+		)
+		if commit.Author.Name != "" {
+			line += fmt.Sprintf(" (%s)", commit.Author.Name)
+		}
+		lines = append(lines, line)
+	}
+
+	if hidden := events.PushCommitCount(userActivity) - len(userActivity.Payload.Commits); hidden > 0 {
+		lines = append(lines, fmt.Sprintf(
+			"... and %d more commits not listed by GitHub",
+			hidden,
+		))
+	}
+
+	return lines
+}
+
+func shortSha(sha string) string {
+	if len(sha) > SHORT_SHA_LENGTH {
+		return sha[:SHORT_SHA_LENGTH]
+	}
+	return sha
+}
+
+func firstLine(message string) string {
+	line, _, _ := strings.Cut(message, "\n")
+	return strings.TrimSpace(line)
+}
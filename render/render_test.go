@@ -0,0 +1,1157 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+func TestPrinter(t *testing.T) {
+	tests := []struct {
+		name           string
+		activities     []events.Event
+		expectedError  bool
+		expectedOutput string
+	}{
+		{
+			name:          "Empty activities list",
+			activities:    []events.Event{},
+			expectedError: true,
+		},
+		{
+			name: "Single activity",
+			activities: []events.Event{
+				{
+					Type: "PushEvent",
+					Repo: struct {
+						Name string `json:"name"`
+					}{Name: "test-repo"},
+					Payload: struct {
+						Ref     string `json:"ref"`
+						RefType string `json:"ref_type"`
+						Size    int    `json:"size"`
+						Before  string `json:"before"`
+						Head    string `json:"head"`
+						Commits []struct {
+							Sha     string `json:"sha"`
+							Message string `json:"message"`
+							Author  struct {
+								Name string `json:"name"`
+							} `json:"author"`
+						} `json:"commits"`
+						Action string `json:"action"`
+						Number int    `json:"number"`
+						Forkee struct {
+							FullName string `json:"full_name"`
+							HTMLURL  string `json:"html_url"`
+							Owner    struct {
+								Login string `json:"login"`
+							} `json:"owner"`
+						} `json:"forkee"`
+						Issue struct {
+							Number  int    `json:"number"`
+							Title   string `json:"title"`
+							HTMLURL string `json:"html_url"`
+						} `json:"issue"`
+						PullReq struct {
+							Title   string `json:"title"`
+							Merged  bool   `json:"merged"`
+							HTMLURL string `json:"html_url"`
+						} `json:"pull_request"`
+						Member struct {
+							Login string `json:"login"`
+						} `json:"member"`
+						Release struct {
+							Name    string `json:"name"`
+							TagName string `json:"tag_name"`
+							HTMLURL string `json:"html_url"`
+						} `json:"release"`
+						Comment struct {
+							Body    string `json:"body"`
+							HTMLURL string `json:"html_url"`
+						} `json:"comment"`
+					}{
+						Commits: []struct {
+							Sha     string `json:"sha"`
+							Message string `json:"message"`
+							Author  struct {
+								Name string `json:"name"`
+							} `json:"author"`
+						}{
+							{Message: "Initial commit"},
+							{Message: "Add feature"},
+						},
+					},
+				},
+			},
+			expectedError:  false,
+			expectedOutput: "  - Pushed 2 commits to test-repo\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := printToString(tt.activities, Options{})
+
+			if tt.expectedError && err == nil {
+				t.Errorf("Expected error but got none")
+			}
+			if !tt.expectedError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if !tt.expectedError && output != tt.expectedOutput {
+				t.Errorf("Expected output %q, got %q", tt.expectedOutput, output)
+			}
+		})
+	}
+}
+
+func TestActivityString(t *testing.T) {
+	tests := []struct {
+		name     string
+		activity events.Event
+		expected string
+		hasError bool
+	}{
+		{
+			name: "PushEvent",
+			activity: events.Event{
+				Type: events.PUSH_EVENT,
+				Repo: struct {
+					Name string `json:"name"`
+				}{Name: "test-repo"},
+				Payload: struct {
+					Ref     string `json:"ref"`
+					RefType string `json:"ref_type"`
+					Size    int    `json:"size"`
+					Before  string `json:"before"`
+					Head    string `json:"head"`
+					Commits []struct {
+						Sha     string `json:"sha"`
+						Message string `json:"message"`
+						Author  struct {
+							Name string `json:"name"`
+						} `json:"author"`
+					} `json:"commits"`
+					Action string `json:"action"`
+					Number int    `json:"number"`
+					Forkee struct {
+						FullName string `json:"full_name"`
+						HTMLURL  string `json:"html_url"`
+						Owner    struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+					} `json:"forkee"`
+					Issue struct {
+						Number  int    `json:"number"`
+						Title   string `json:"title"`
+						HTMLURL string `json:"html_url"`
+					} `json:"issue"`
+					PullReq struct {
+						Title   string `json:"title"`
+						Merged  bool   `json:"merged"`
+						HTMLURL string `json:"html_url"`
+					} `json:"pull_request"`
+					Member struct {
+						Login string `json:"login"`
+					} `json:"member"`
+					Release struct {
+						Name    string `json:"name"`
+						TagName string `json:"tag_name"`
+						HTMLURL string `json:"html_url"`
+					} `json:"release"`
+					Comment struct {
+						Body    string `json:"body"`
+						HTMLURL string `json:"html_url"`
+					} `json:"comment"`
+				}{
+					Commits: []struct {
+						Sha     string `json:"sha"`
+						Message string `json:"message"`
+						Author  struct {
+							Name string `json:"name"`
+						} `json:"author"`
+					}{
+						{Message: "commit1"},
+						{Message: "commit2"},
+					},
+				},
+			},
+			expected: "Pushed 2 commits to test-repo",
+			hasError: false,
+		},
+		{
+			name: "CreateEvent - repository",
+			activity: events.Event{
+				Type: events.CREATE_EVENT,
+				Repo: struct {
+					Name string `json:"name"`
+				}{Name: "new-repo"},
+				Payload: struct {
+					Ref     string `json:"ref"`
+					RefType string `json:"ref_type"`
+					Size    int    `json:"size"`
+					Before  string `json:"before"`
+					Head    string `json:"head"`
+					Commits []struct {
+						Sha     string `json:"sha"`
+						Message string `json:"message"`
+						Author  struct {
+							Name string `json:"name"`
+						} `json:"author"`
+					} `json:"commits"`
+					Action string `json:"action"`
+					Number int    `json:"number"`
+					Forkee struct {
+						FullName string `json:"full_name"`
+						HTMLURL  string `json:"html_url"`
+						Owner    struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+					} `json:"forkee"`
+					Issue struct {
+						Number  int    `json:"number"`
+						Title   string `json:"title"`
+						HTMLURL string `json:"html_url"`
+					} `json:"issue"`
+					PullReq struct {
+						Title   string `json:"title"`
+						Merged  bool   `json:"merged"`
+						HTMLURL string `json:"html_url"`
+					} `json:"pull_request"`
+					Member struct {
+						Login string `json:"login"`
+					} `json:"member"`
+					Release struct {
+						Name    string `json:"name"`
+						TagName string `json:"tag_name"`
+						HTMLURL string `json:"html_url"`
+					} `json:"release"`
+					Comment struct {
+						Body    string `json:"body"`
+						HTMLURL string `json:"html_url"`
+					} `json:"comment"`
+				}{
+					RefType: "repository",
+				},
+			},
+			expected: "Created repository at new-repo",
+			hasError: false,
+		},
+		{
+			name: "CreateEvent - branch",
+			activity: events.Event{
+				Type: events.CREATE_EVENT,
+				Repo: struct {
+					Name string `json:"name"`
+				}{Name: "test-repo"},
+				Payload: struct {
+					Ref     string `json:"ref"`
+					RefType string `json:"ref_type"`
+					Size    int    `json:"size"`
+					Before  string `json:"before"`
+					Head    string `json:"head"`
+					Commits []struct {
+						Sha     string `json:"sha"`
+						Message string `json:"message"`
+						Author  struct {
+							Name string `json:"name"`
+						} `json:"author"`
+					} `json:"commits"`
+					Action string `json:"action"`
+					Number int    `json:"number"`
+					Forkee struct {
+						FullName string `json:"full_name"`
+						HTMLURL  string `json:"html_url"`
+						Owner    struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+					} `json:"forkee"`
+					Issue struct {
+						Number  int    `json:"number"`
+						Title   string `json:"title"`
+						HTMLURL string `json:"html_url"`
+					} `json:"issue"`
+					PullReq struct {
+						Title   string `json:"title"`
+						Merged  bool   `json:"merged"`
+						HTMLURL string `json:"html_url"`
+					} `json:"pull_request"`
+					Member struct {
+						Login string `json:"login"`
+					} `json:"member"`
+					Release struct {
+						Name    string `json:"name"`
+						TagName string `json:"tag_name"`
+						HTMLURL string `json:"html_url"`
+					} `json:"release"`
+					Comment struct {
+						Body    string `json:"body"`
+						HTMLURL string `json:"html_url"`
+					} `json:"comment"`
+				}{
+					Ref:     "feature-branch",
+					RefType: "branch",
+				},
+			},
+			expected: "Created branch 'feature-branch' at test-repo",
+			hasError: false,
+		},
+		{
+			name: "WatchEvent - started",
+			activity: events.Event{
+				Type: events.WATCH_EVENT,
+				Repo: struct {
+					Name string `json:"name"`
+				}{Name: "watched-repo"},
+				Payload: struct {
+					Ref     string `json:"ref"`
+					RefType string `json:"ref_type"`
+					Size    int    `json:"size"`
+					Before  string `json:"before"`
+					Head    string `json:"head"`
+					Commits []struct {
+						Sha     string `json:"sha"`
+						Message string `json:"message"`
+						Author  struct {
+							Name string `json:"name"`
+						} `json:"author"`
+					} `json:"commits"`
+					Action string `json:"action"`
+					Number int    `json:"number"`
+					Forkee struct {
+						FullName string `json:"full_name"`
+						HTMLURL  string `json:"html_url"`
+						Owner    struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+					} `json:"forkee"`
+					Issue struct {
+						Number  int    `json:"number"`
+						Title   string `json:"title"`
+						HTMLURL string `json:"html_url"`
+					} `json:"issue"`
+					PullReq struct {
+						Title   string `json:"title"`
+						Merged  bool   `json:"merged"`
+						HTMLURL string `json:"html_url"`
+					} `json:"pull_request"`
+					Member struct {
+						Login string `json:"login"`
+					} `json:"member"`
+					Release struct {
+						Name    string `json:"name"`
+						TagName string `json:"tag_name"`
+						HTMLURL string `json:"html_url"`
+					} `json:"release"`
+					Comment struct {
+						Body    string `json:"body"`
+						HTMLURL string `json:"html_url"`
+					} `json:"comment"`
+				}{
+					Action: "started",
+				},
+			},
+			expected: "Started watching watched-repo",
+			hasError: false,
+		},
+		{
+			name: "WatchEvent - ended",
+			activity: events.Event{
+				Type: events.WATCH_EVENT,
+				Repo: struct {
+					Name string `json:"name"`
+				}{Name: "watched-repo"},
+				Payload: struct {
+					Ref     string `json:"ref"`
+					RefType string `json:"ref_type"`
+					Size    int    `json:"size"`
+					Before  string `json:"before"`
+					Head    string `json:"head"`
+					Commits []struct {
+						Sha     string `json:"sha"`
+						Message string `json:"message"`
+						Author  struct {
+							Name string `json:"name"`
+						} `json:"author"`
+					} `json:"commits"`
+					Action string `json:"action"`
+					Number int    `json:"number"`
+					Forkee struct {
+						FullName string `json:"full_name"`
+						HTMLURL  string `json:"html_url"`
+						Owner    struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+					} `json:"forkee"`
+					Issue struct {
+						Number  int    `json:"number"`
+						Title   string `json:"title"`
+						HTMLURL string `json:"html_url"`
+					} `json:"issue"`
+					PullReq struct {
+						Title   string `json:"title"`
+						Merged  bool   `json:"merged"`
+						HTMLURL string `json:"html_url"`
+					} `json:"pull_request"`
+					Member struct {
+						Login string `json:"login"`
+					} `json:"member"`
+					Release struct {
+						Name    string `json:"name"`
+						TagName string `json:"tag_name"`
+						HTMLURL string `json:"html_url"`
+					} `json:"release"`
+					Comment struct {
+						Body    string `json:"body"`
+						HTMLURL string `json:"html_url"`
+					} `json:"comment"`
+				}{
+					Action: "stopped",
+				},
+			},
+			expected: "Ended watching watched-repo",
+			hasError: false,
+		},
+		{
+			name: "DeleteEvent",
+			activity: events.Event{
+				Type: events.DELETE_EVENT,
+				Repo: struct {
+					Name string `json:"name"`
+				}{Name: "test-repo"},
+				Payload: struct {
+					Ref     string `json:"ref"`
+					RefType string `json:"ref_type"`
+					Size    int    `json:"size"`
+					Before  string `json:"before"`
+					Head    string `json:"head"`
+					Commits []struct {
+						Sha     string `json:"sha"`
+						Message string `json:"message"`
+						Author  struct {
+							Name string `json:"name"`
+						} `json:"author"`
+					} `json:"commits"`
+					Action string `json:"action"`
+					Number int    `json:"number"`
+					Forkee struct {
+						FullName string `json:"full_name"`
+						HTMLURL  string `json:"html_url"`
+						Owner    struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+					} `json:"forkee"`
+					Issue struct {
+						Number  int    `json:"number"`
+						Title   string `json:"title"`
+						HTMLURL string `json:"html_url"`
+					} `json:"issue"`
+					PullReq struct {
+						Title   string `json:"title"`
+						Merged  bool   `json:"merged"`
+						HTMLURL string `json:"html_url"`
+					} `json:"pull_request"`
+					Member struct {
+						Login string `json:"login"`
+					} `json:"member"`
+					Release struct {
+						Name    string `json:"name"`
+						TagName string `json:"tag_name"`
+						HTMLURL string `json:"html_url"`
+					} `json:"release"`
+					Comment struct {
+						Body    string `json:"body"`
+						HTMLURL string `json:"html_url"`
+					} `json:"comment"`
+				}{
+					Ref:     "old-branch",
+					RefType: "branch",
+				},
+			},
+			expected: "Deleted branch 'old-branch' at test-repo",
+			hasError: false,
+		},
+		{
+			name: "ForkEvent",
+			activity: events.Event{
+				Type: events.FORK_EVENT,
+				Repo: struct {
+					Name string `json:"name"`
+				}{Name: "original-repo"},
+				Payload: struct {
+					Ref     string `json:"ref"`
+					RefType string `json:"ref_type"`
+					Size    int    `json:"size"`
+					Before  string `json:"before"`
+					Head    string `json:"head"`
+					Commits []struct {
+						Sha     string `json:"sha"`
+						Message string `json:"message"`
+						Author  struct {
+							Name string `json:"name"`
+						} `json:"author"`
+					} `json:"commits"`
+					Action string `json:"action"`
+					Number int    `json:"number"`
+					Forkee struct {
+						FullName string `json:"full_name"`
+						HTMLURL  string `json:"html_url"`
+						Owner    struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+					} `json:"forkee"`
+					Issue struct {
+						Number  int    `json:"number"`
+						Title   string `json:"title"`
+						HTMLURL string `json:"html_url"`
+					} `json:"issue"`
+					PullReq struct {
+						Title   string `json:"title"`
+						Merged  bool   `json:"merged"`
+						HTMLURL string `json:"html_url"`
+					} `json:"pull_request"`
+					Member struct {
+						Login string `json:"login"`
+					} `json:"member"`
+					Release struct {
+						Name    string `json:"name"`
+						TagName string `json:"tag_name"`
+						HTMLURL string `json:"html_url"`
+					} `json:"release"`
+					Comment struct {
+						Body    string `json:"body"`
+						HTMLURL string `json:"html_url"`
+					} `json:"comment"`
+				}{
+					Forkee: struct {
+						FullName string `json:"full_name"`
+						HTMLURL  string `json:"html_url"`
+						Owner    struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+					}{
+						FullName: "user/forked-repo",
+					},
+				},
+			},
+			expected: "Forked original-repo to user/forked-repo",
+			hasError: false,
+		},
+		{
+			name: "IssuesEvent",
+			activity: events.Event{
+				Type: events.ISSUES_EVENT,
+				Repo: struct {
+					Name string `json:"name"`
+				}{Name: "test-repo"},
+				Payload: struct {
+					Ref     string `json:"ref"`
+					RefType string `json:"ref_type"`
+					Size    int    `json:"size"`
+					Before  string `json:"before"`
+					Head    string `json:"head"`
+					Commits []struct {
+						Sha     string `json:"sha"`
+						Message string `json:"message"`
+						Author  struct {
+							Name string `json:"name"`
+						} `json:"author"`
+					} `json:"commits"`
+					Action string `json:"action"`
+					Number int    `json:"number"`
+					Forkee struct {
+						FullName string `json:"full_name"`
+						HTMLURL  string `json:"html_url"`
+						Owner    struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+					} `json:"forkee"`
+					Issue struct {
+						Number  int    `json:"number"`
+						Title   string `json:"title"`
+						HTMLURL string `json:"html_url"`
+					} `json:"issue"`
+					PullReq struct {
+						Title   string `json:"title"`
+						Merged  bool   `json:"merged"`
+						HTMLURL string `json:"html_url"`
+					} `json:"pull_request"`
+					Member struct {
+						Login string `json:"login"`
+					} `json:"member"`
+					Release struct {
+						Name    string `json:"name"`
+						TagName string `json:"tag_name"`
+						HTMLURL string `json:"html_url"`
+					} `json:"release"`
+					Comment struct {
+						Body    string `json:"body"`
+						HTMLURL string `json:"html_url"`
+					} `json:"comment"`
+				}{
+					Action: "opened",
+					Issue: struct {
+						Number  int    `json:"number"`
+						Title   string `json:"title"`
+						HTMLURL string `json:"html_url"`
+					}{
+						Title: "Bug report",
+					},
+				},
+			},
+			expected: "Issue 'Bug report' opened at test-repo",
+			hasError: false,
+		},
+		{
+			name: "IssueCommentEvent",
+			activity: events.Event{
+				Type: events.ISSUES_COMMENT_EVENT,
+				Repo: struct {
+					Name string `json:"name"`
+				}{Name: "test-repo"},
+				Payload: struct {
+					Ref     string `json:"ref"`
+					RefType string `json:"ref_type"`
+					Size    int    `json:"size"`
+					Before  string `json:"before"`
+					Head    string `json:"head"`
+					Commits []struct {
+						Sha     string `json:"sha"`
+						Message string `json:"message"`
+						Author  struct {
+							Name string `json:"name"`
+						} `json:"author"`
+					} `json:"commits"`
+					Action string `json:"action"`
+					Number int    `json:"number"`
+					Forkee struct {
+						FullName string `json:"full_name"`
+						HTMLURL  string `json:"html_url"`
+						Owner    struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+					} `json:"forkee"`
+					Issue struct {
+						Number  int    `json:"number"`
+						Title   string `json:"title"`
+						HTMLURL string `json:"html_url"`
+					} `json:"issue"`
+					PullReq struct {
+						Title   string `json:"title"`
+						Merged  bool   `json:"merged"`
+						HTMLURL string `json:"html_url"`
+					} `json:"pull_request"`
+					Member struct {
+						Login string `json:"login"`
+					} `json:"member"`
+					Release struct {
+						Name    string `json:"name"`
+						TagName string `json:"tag_name"`
+						HTMLURL string `json:"html_url"`
+					} `json:"release"`
+					Comment struct {
+						Body    string `json:"body"`
+						HTMLURL string `json:"html_url"`
+					} `json:"comment"`
+				}{
+					Issue: struct {
+						Number  int    `json:"number"`
+						Title   string `json:"title"`
+						HTMLURL string `json:"html_url"`
+					}{
+						Title: "Bug report",
+					},
+				},
+			},
+			expected: "Commented at issue 'Bug report' at test-repo",
+			hasError: false,
+		},
+		{
+			name: "PullRequestEvent",
+			activity: events.Event{
+				Type: events.PULL_REQUEST_EVENT,
+				Repo: struct {
+					Name string `json:"name"`
+				}{Name: "test-repo"},
+				Payload: struct {
+					Ref     string `json:"ref"`
+					RefType string `json:"ref_type"`
+					Size    int    `json:"size"`
+					Before  string `json:"before"`
+					Head    string `json:"head"`
+					Commits []struct {
+						Sha     string `json:"sha"`
+						Message string `json:"message"`
+						Author  struct {
+							Name string `json:"name"`
+						} `json:"author"`
+					} `json:"commits"`
+					Action string `json:"action"`
+					Number int    `json:"number"`
+					Forkee struct {
+						FullName string `json:"full_name"`
+						HTMLURL  string `json:"html_url"`
+						Owner    struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+					} `json:"forkee"`
+					Issue struct {
+						Number  int    `json:"number"`
+						Title   string `json:"title"`
+						HTMLURL string `json:"html_url"`
+					} `json:"issue"`
+					PullReq struct {
+						Title   string `json:"title"`
+						Merged  bool   `json:"merged"`
+						HTMLURL string `json:"html_url"`
+					} `json:"pull_request"`
+					Member struct {
+						Login string `json:"login"`
+					} `json:"member"`
+					Release struct {
+						Name    string `json:"name"`
+						TagName string `json:"tag_name"`
+						HTMLURL string `json:"html_url"`
+					} `json:"release"`
+					Comment struct {
+						Body    string `json:"body"`
+						HTMLURL string `json:"html_url"`
+					} `json:"comment"`
+				}{
+					Action: "opened",
+					PullReq: struct {
+						Title   string `json:"title"`
+						Merged  bool   `json:"merged"`
+						HTMLURL string `json:"html_url"`
+					}{
+						Title: "Add new feature",
+					},
+				},
+			},
+			expected: "Pull request 'Add new feature' opened at test-repo",
+			hasError: false,
+		},
+		{
+			name: "PublicEvent",
+			activity: events.Event{
+				Type: events.PUBLIC_EVENT,
+				Repo: struct {
+					Name string `json:"name"`
+				}{Name: "test-repo"},
+			},
+			expected: "Repo test-repo is now public",
+			hasError: false,
+		},
+		{
+			name: "MemberEvent",
+			activity: events.Event{
+				Type: events.MEMBER_EVENT,
+				Repo: struct {
+					Name string `json:"name"`
+				}{Name: "test-repo"},
+				Payload: struct {
+					Ref     string `json:"ref"`
+					RefType string `json:"ref_type"`
+					Size    int    `json:"size"`
+					Before  string `json:"before"`
+					Head    string `json:"head"`
+					Commits []struct {
+						Sha     string `json:"sha"`
+						Message string `json:"message"`
+						Author  struct {
+							Name string `json:"name"`
+						} `json:"author"`
+					} `json:"commits"`
+					Action string `json:"action"`
+					Number int    `json:"number"`
+					Forkee struct {
+						FullName string `json:"full_name"`
+						HTMLURL  string `json:"html_url"`
+						Owner    struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+					} `json:"forkee"`
+					Issue struct {
+						Number  int    `json:"number"`
+						Title   string `json:"title"`
+						HTMLURL string `json:"html_url"`
+					} `json:"issue"`
+					PullReq struct {
+						Title   string `json:"title"`
+						Merged  bool   `json:"merged"`
+						HTMLURL string `json:"html_url"`
+					} `json:"pull_request"`
+					Member struct {
+						Login string `json:"login"`
+					} `json:"member"`
+					Release struct {
+						Name    string `json:"name"`
+						TagName string `json:"tag_name"`
+						HTMLURL string `json:"html_url"`
+					} `json:"release"`
+					Comment struct {
+						Body    string `json:"body"`
+						HTMLURL string `json:"html_url"`
+					} `json:"comment"`
+				}{
+					Member: struct {
+						Login string `json:"login"`
+					}{
+						Login: "newuser",
+					},
+				},
+			},
+			expected: "Added newuser to test-repo",
+			hasError: false,
+		},
+		{
+			name: "ReleaseEvent",
+			activity: events.Event{
+				Type: events.RELEASE_EVENT,
+				Repo: struct {
+					Name string `json:"name"`
+				}{Name: "test-repo"},
+				Payload: struct {
+					Ref     string `json:"ref"`
+					RefType string `json:"ref_type"`
+					Size    int    `json:"size"`
+					Before  string `json:"before"`
+					Head    string `json:"head"`
+					Commits []struct {
+						Sha     string `json:"sha"`
+						Message string `json:"message"`
+						Author  struct {
+							Name string `json:"name"`
+						} `json:"author"`
+					} `json:"commits"`
+					Action string `json:"action"`
+					Number int    `json:"number"`
+					Forkee struct {
+						FullName string `json:"full_name"`
+						HTMLURL  string `json:"html_url"`
+						Owner    struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+					} `json:"forkee"`
+					Issue struct {
+						Number  int    `json:"number"`
+						Title   string `json:"title"`
+						HTMLURL string `json:"html_url"`
+					} `json:"issue"`
+					PullReq struct {
+						Title   string `json:"title"`
+						Merged  bool   `json:"merged"`
+						HTMLURL string `json:"html_url"`
+					} `json:"pull_request"`
+					Member struct {
+						Login string `json:"login"`
+					} `json:"member"`
+					Release struct {
+						Name    string `json:"name"`
+						TagName string `json:"tag_name"`
+						HTMLURL string `json:"html_url"`
+					} `json:"release"`
+					Comment struct {
+						Body    string `json:"body"`
+						HTMLURL string `json:"html_url"`
+					} `json:"comment"`
+				}{
+					Release: struct {
+						Name    string `json:"name"`
+						TagName string `json:"tag_name"`
+						HTMLURL string `json:"html_url"`
+					}{
+						Name: "v1.0.0",
+					},
+				},
+			},
+			expected: "Released 'v1.0.0' at test-repo",
+			hasError: false,
+		},
+		{
+			name: "Unknown event type",
+			activity: events.Event{
+				Type: "UnknownEvent",
+				Repo: struct {
+					Name string `json:"name"`
+				}{Name: "test-repo"},
+			},
+			expected: "UnknownEvent to test-repo",
+			hasError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ActivityString(tt.activity)
+
+			if tt.hasError && err == nil {
+				t.Errorf("Expected error but got none")
+			}
+			if !tt.hasError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestPrinterMultipleActivities(t *testing.T) {
+	activities := []events.Event{
+		{
+			Type: events.PUSH_EVENT,
+			Repo: struct {
+				Name string `json:"name"`
+			}{Name: "repo1"},
+			Payload: struct {
+				Ref     string `json:"ref"`
+				RefType string `json:"ref_type"`
+				Size    int    `json:"size"`
+				Before  string `json:"before"`
+				Head    string `json:"head"`
+				Commits []struct {
+					Sha     string `json:"sha"`
+					Message string `json:"message"`
+					Author  struct {
+						Name string `json:"name"`
+					} `json:"author"`
+				} `json:"commits"`
+				Action string `json:"action"`
+				Number int    `json:"number"`
+				Forkee struct {
+					FullName string `json:"full_name"`
+					HTMLURL  string `json:"html_url"`
+					Owner    struct {
+						Login string `json:"login"`
+					} `json:"owner"`
+				} `json:"forkee"`
+				Issue struct {
+					Number  int    `json:"number"`
+					Title   string `json:"title"`
+					HTMLURL string `json:"html_url"`
+				} `json:"issue"`
+				PullReq struct {
+					Title   string `json:"title"`
+					Merged  bool   `json:"merged"`
+					HTMLURL string `json:"html_url"`
+				} `json:"pull_request"`
+				Member struct {
+					Login string `json:"login"`
+				} `json:"member"`
+				Release struct {
+					Name    string `json:"name"`
+					TagName string `json:"tag_name"`
+					HTMLURL string `json:"html_url"`
+				} `json:"release"`
+				Comment struct {
+					Body    string `json:"body"`
+					HTMLURL string `json:"html_url"`
+				} `json:"comment"`
+			}{
+				Commits: []struct {
+					Sha     string `json:"sha"`
+					Message string `json:"message"`
+					Author  struct {
+						Name string `json:"name"`
+					} `json:"author"`
+				}{{Message: "commit1"}},
+			},
+		},
+		{
+			Type: events.WATCH_EVENT,
+			Repo: struct {
+				Name string `json:"name"`
+			}{Name: "repo2"},
+			Payload: struct {
+				Ref     string `json:"ref"`
+				RefType string `json:"ref_type"`
+				Size    int    `json:"size"`
+				Before  string `json:"before"`
+				Head    string `json:"head"`
+				Commits []struct {
+					Sha     string `json:"sha"`
+					Message string `json:"message"`
+					Author  struct {
+						Name string `json:"name"`
+					} `json:"author"`
+				} `json:"commits"`
+				Action string `json:"action"`
+				Number int    `json:"number"`
+				Forkee struct {
+					FullName string `json:"full_name"`
+					HTMLURL  string `json:"html_url"`
+					Owner    struct {
+						Login string `json:"login"`
+					} `json:"owner"`
+				} `json:"forkee"`
+				Issue struct {
+					Number  int    `json:"number"`
+					Title   string `json:"title"`
+					HTMLURL string `json:"html_url"`
+				} `json:"issue"`
+				PullReq struct {
+					Title   string `json:"title"`
+					Merged  bool   `json:"merged"`
+					HTMLURL string `json:"html_url"`
+				} `json:"pull_request"`
+				Member struct {
+					Login string `json:"login"`
+				} `json:"member"`
+				Release struct {
+					Name    string `json:"name"`
+					TagName string `json:"tag_name"`
+					HTMLURL string `json:"html_url"`
+				} `json:"release"`
+				Comment struct {
+					Body    string `json:"body"`
+					HTMLURL string `json:"html_url"`
+				} `json:"comment"`
+			}{
+				Action: "started",
+			},
+		},
+	}
+
+	output, err := printToString(activities, Options{})
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	expectedLines := []string{
+		"  - Pushed 1 commits to repo1",
+		"  - Started watching repo2",
+	}
+
+	for _, expectedLine := range expectedLines {
+		if !strings.Contains(output, expectedLine) {
+			t.Errorf("Expected output to contain %q, got %q", expectedLine, output)
+		}
+	}
+}
+
+func eventFromJSON(t *testing.T, data string) events.Event {
+	t.Helper()
+
+	var event events.Event
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		t.Fatalf("Couldn't decode test event: %v", err)
+	}
+
+	return event
+}
+
+func printToString(activities []events.Event, opts Options) (string, error) {
+	var buf bytes.Buffer
+	err := Print(&buf, activities, opts)
+	return buf.String(), err
+}
+
+func TestPushDetails(t *testing.T) {
+	activity := eventFromJSON(t, `{
+		"type": "PushEvent",
+		"repo": {"name": "test-repo"},
+		"payload": {
+			"ref": "refs/heads/main",
+			"size": 23,
+			"commits": [
+				{"sha": "0123456789abcdef", "message": "Fix parser\n\nLonger body", "author": {"name": "Alice"}},
+				{"sha": "abc", "message": "Bump version"}
+			]
+		}
+	}`)
+
+	expected := []string{
+		"Branch: main",
+		"0123456 Fix parser (Alice)",
+		"abc Bump version",
+		"... and 21 more commits not listed by GitHub",
+	}
+
+	result := pushDetails(activity)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("pushDetails() = %q, want %q", result, expected)
+	}
+
+	line, err := ActivityString(activity)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if line != "Pushed 23 commits to test-repo" {
+		t.Errorf("Expected truncated push to report payload size, got %q", line)
+	}
+}
+
+func TestDetails(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    string
+		expected []string
+	}{
+		{
+			name:     "Merged pull request",
+			event:    `{"type":"PullRequestEvent","payload":{"action":"closed","number":7,"pull_request":{"title":"Add parser","merged":true}}}`,
+			expected: []string{"Pull request #7: Add parser (closed), merged"},
+		},
+		{
+			name:     "Issue comment",
+			event:    `{"type":"IssueCommentEvent","payload":{"issue":{"number":3,"title":"Crash"},"comment":{"body":"Same here.\nOn 1.2 too."}}}`,
+			expected: []string{"Issue #3: Crash", "Same here.", "On 1.2 too."},
+		},
+		{
+			name:     "Created branch",
+			event:    `{"type":"CreateEvent","payload":{"ref":"feature","ref_type":"branch"}}`,
+			expected: []string{"Branch: feature"},
+		},
+		{
+			name:  "Collapsed pushes",
+			event: `{"type":"PushEvent"}`,
+		},
+		{
+			name:  "Event without details",
+			event: `{"type":"WatchEvent","payload":{"action":"started"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Details(eventFromJSON(t, tt.event)); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Details() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+
+	first := eventFromJSON(t, `{"type":"PushEvent","payload":{"commits":[{"sha":"aaa","message":"First"}]}}`)
+	second := eventFromJSON(t, `{"type":"PushEvent","payload":{"commits":[{"sha":"bbb","message":"Second"}]}}`)
+	collapsed := events.Collapse([]events.Event{first, second})
+	if result := Details(collapsed[0]); !reflect.DeepEqual(result, []string{"aaa First", "bbb Second"}) {
+		t.Errorf("Expected the commits of both pushes, got %q", result)
+	}
+}
+
+func TestPrinterVerbose(t *testing.T) {
+	activities := []events.Event{
+		eventFromJSON(t, `{"type":"PushEvent","repo":{"name":"test-repo"},"payload":{"ref":"refs/heads/dev","commits":[{"sha":"0123456789","message":"Add feature"}]}}`),
+		eventFromJSON(t, `{"type":"WatchEvent","repo":{"name":"other-repo"},"payload":{"action":"started"}}`),
+	}
+
+	output, err := printToString(activities, Options{Verbose: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "  - Pushed 1 commits to test-repo\n" +
+		"      Branch: dev\n" +
+		"      0123456 Add feature\n" +
+		"  - Started watching other-repo\n"
+	if output != expected {
+		t.Errorf("Expected output %q, got %q", expected, output)
+	}
+}
@@ -0,0 +1,511 @@
+// Package tui is a full-screen browser for GitHub events, drawn with plain
+// ANSI escape sequences.
+package tui
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+	"github.com/dmitriy-zverev/github-activity/filter"
+	"github.com/dmitriy-zverev/github-activity/render"
+)
+
+// Options controls what Run shows and where further events come from.
+type Options struct {
+	// Title heads the screen, e.g. the user whose events are shown
+	Title string
+	// Host is the GitHub web host event URLs point to, github.com when empty
+	Host string
+	// Filters are applied to every page before it is shown
+	Filters filter.Options
+	// Collapse merges runs of similar events like the text output does
+	Collapse bool
+	// Location is the time zone event times are shown in, UTC when nil
+	Location *time.Location
+	// Page is the page the initial events came from
+	Page int
+	// Fetch loads another page of events. It is nil when every event was
+	// loaded up front.
+	Fetch func(ctx context.Context, page int) ([]events.Event, error)
+}
+
+// item is an event in the list with the text it is shown and searched as.
+type item struct {
+	event   events.Event
+	summary string
+	search  string
+}
+
+// browser is the state of the screen: the events loaded so far, the ones the
+// query matches, the selection and the scroll position.
+type browser struct {
+	opts     Options
+	loaded   []events.Event
+	items    []item
+	visible  []int
+	cursor   int
+	offset   int
+	height   int
+	query    string
+	typing   bool
+	page     int
+	done     bool
+	wantMore bool
+	status   string
+	open     func(url string) error
+}
+
+// Run shows activities in the browser until the user quits. Further pages
+// are fetched through opts.Fetch as the selection reaches the end of the list,
+// or until something matches when the filters leave the list empty.
+func Run(ctx context.Context, activities []events.Event, opts Options) error {
+	term, err := openTerminal()
+	if err != nil {
+		return fmt.Errorf("interactive mode needs a terminal: %w", err)
+	}
+	defer term.restore()
+
+	b := newBrowser(activities, opts)
+	for {
+		width, height := term.size()
+		for b.wantsMore() {
+			b.status = fmt.Sprintf("Loading page %d...", b.page+1)
+			if err := term.draw(b.view(width, height)); err != nil {
+				return err
+			}
+			b.loadMore(ctx)
+		}
+
+		if err := term.draw(b.view(width, height)); err != nil {
+			return err
+		}
+
+		keys, err := term.readKeys()
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if b.handleKey(key) {
+				return nil
+			}
+		}
+	}
+}
+
+func newBrowser(activities []events.Event, opts Options) *browser {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+
+	b := &browser{
+		opts:   opts,
+		loaded: activities,
+		page:   opts.Page,
+		done:   opts.Fetch == nil,
+		height: DEFAULT_HEIGHT,
+		open:   openURL,
+	}
+	b.rebuild()
+	if len(b.visible) == 0 {
+		b.wantMore = true
+	}
+	return b
+}
+
+// rebuild filters and collapses the loaded events into items.
+func (b *browser) rebuild() {
+	// A NoResultError just leaves the list empty until more pages arrive
+	activities, _ := filter.Apply(b.loaded, b.opts.Filters)
+	if b.opts.Collapse {
+		activities = events.Collapse(activities)
+	}
+
+	b.items = make([]item, 0, len(activities))
+	for _, activity := range activities {
+		summary, err := render.ActivityString(activity)
+		if err != nil {
+			summary = activity.Type
+		}
+
+		search := []string{summary, activity.Type, activity.Repo.Name, activity.Actor.Login}
+		search = append(search, render.Details(activity)...)
+		b.items = append(b.items, item{
+			event:   activity,
+			summary: summary,
+			search:  strings.ToLower(strings.Join(search, "\n")),
+		})
+	}
+
+	b.applyQuery()
+}
+
+// applyQuery narrows the list to the items matching the query, keeping the
+// selected item selected when it still matches.
+func (b *browser) applyQuery() {
+	selected := -1
+	if b.cursor < len(b.visible) {
+		selected = b.visible[b.cursor]
+	}
+
+	query := strings.ToLower(b.query)
+	b.visible = b.visible[:0]
+	b.cursor = 0
+	for i, item := range b.items {
+		if !strings.Contains(item.search, query) {
+			continue
+		}
+		if i <= selected {
+			b.cursor = len(b.visible)
+		}
+		b.visible = append(b.visible, i)
+	}
+}
+
+func (b *browser) selected() (item, bool) {
+	if b.cursor >= len(b.visible) {
+		return item{}, false
+	}
+	return b.items[b.visible[b.cursor]], true
+}
+
+// handleKey applies a key press and reports whether the user asked to quit.
+func (b *browser) handleKey(key string) bool {
+	b.status = ""
+
+	if b.typing {
+		switch key {
+		case KEY_ENTER:
+			b.typing = false
+			return false
+		case KEY_ESCAPE:
+			b.typing = false
+			b.query = ""
+			b.applyQuery()
+			return false
+		case KEY_BACKSPACE:
+			if b.query != "" {
+				_, size := utf8.DecodeLastRuneInString(b.query)
+				b.query = b.query[:len(b.query)-size]
+				b.applyQuery()
+			}
+			return false
+		}
+
+		if utf8.RuneCountInString(key) == 1 {
+			b.query += key
+			b.applyQuery()
+			return false
+		}
+	}
+
+	switch key {
+	case "q", KEY_CTRL_C:
+		return true
+	case "/":
+		b.typing = true
+	case KEY_ESCAPE:
+		b.query = ""
+		b.applyQuery()
+	case KEY_UP, "k":
+		b.move(-1)
+	case KEY_DOWN, "j":
+		b.move(1)
+	case KEY_PAGE_UP:
+		b.move(-b.listHeight())
+	case KEY_PAGE_DOWN:
+		b.move(b.listHeight())
+	case KEY_HOME, "g":
+		b.move(-len(b.visible))
+	case KEY_END, "G":
+		b.move(len(b.visible))
+	case KEY_ENTER, "o":
+		b.openSelected()
+	case "n":
+		b.wantMore = true
+		if b.done {
+			b.status = "No more events"
+		}
+	}
+
+	return false
+}
+
+// move shifts the selection by delta. Reaching the end of the list asks for
+// the next page.
+func (b *browser) move(delta int) {
+	b.cursor = max(min(b.cursor+delta, len(b.visible)-1), 0)
+	if delta > 0 && b.cursor >= len(b.visible)-1 {
+		b.wantMore = true
+	}
+}
+
+func (b *browser) openSelected() {
+	selected, ok := b.selected()
+	if !ok {
+		return
+	}
+
+	url := events.HTMLURL(selected.event, b.opts.Host)
+	if !safeURL(url, b.opts.Host) {
+		b.status = fmt.Sprintf("Won't open %s: not an https URL on %s", url, cmp.Or(b.opts.Host, events.DEFAULT_WEB_HOST))
+		return
+	}
+	if err := b.open(url); err != nil {
+		b.status = fmt.Sprintf("Couldn't open %s: %v", url, err)
+		return
+	}
+	b.status = "Opened " + url
+}
+
+func (b *browser) wantsMore() bool {
+	return b.wantMore && !b.done
+}
+
+// loadMore fetches the page after the last one loaded. An empty page means
+// there are no more events.
+func (b *browser) loadMore(ctx context.Context) {
+	b.wantMore = false
+
+	next := b.page + 1
+	activities, err := b.opts.Fetch(ctx, next)
+	if err != nil {
+		b.status = fmt.Sprintf("Couldn't load page %d: %v", next, err)
+		return
+	}
+	if len(activities) == 0 {
+		b.done = true
+		b.status = "No more events"
+		return
+	}
+
+	b.page = next
+	b.loaded = events.Merge(b.loaded, activities)
+	b.status = ""
+	b.rebuild()
+
+	// Keep going while filters leave nothing to show
+	if len(b.visible) == 0 {
+		b.wantMore = true
+	}
+}
+
+// listHeight is how many rows of the last drawn screen held the list.
+func (b *browser) listHeight() int {
+	return max((b.height-3)/2, MIN_LIST_HEIGHT)
+}
+
+// view lays the screen out as height lines of at most width characters: a
+// header, the list, the details of the selected event and a status line.
+func (b *browser) view(width, height int) []string {
+	b.height = height
+	listHeight := b.listHeight()
+	detailHeight := max(height-listHeight-3, 0)
+
+	if b.cursor < b.offset {
+		b.offset = b.cursor
+	}
+	if b.cursor >= b.offset+listHeight {
+		b.offset = b.cursor - listHeight + 1
+	}
+
+	lines := make([]string, 0, height)
+	lines = append(lines, REVERSE+BOLD+pad(b.header(), width)+RESET)
+
+	for row := b.offset; row < b.offset+listHeight; row++ {
+		if row >= len(b.visible) {
+			lines = append(lines, "")
+			continue
+		}
+
+		item := b.items[b.visible[row]]
+		line := fmt.Sprintf(" %s  %s", item.event.CreatedAt.In(b.opts.Location).Format(DATE_TIME_FORMAT), item.summary)
+		if row == b.cursor {
+			lines = append(lines, REVERSE+pad(line, width)+RESET)
+			continue
+		}
+		lines = append(lines, truncate(line, width))
+	}
+
+	lines = append(lines, DIM+strings.Repeat(SEPARATOR, max(width, 0))+RESET)
+
+	details := b.details()
+	for row := range detailHeight {
+		switch {
+		case row == 0 && len(details) > 0:
+			lines = append(lines, BOLD+truncate(" "+details[row], width)+RESET)
+		case row < len(details):
+			lines = append(lines, truncate(" "+details[row], width))
+		default:
+			lines = append(lines, "")
+		}
+	}
+
+	lines = append(lines, truncate(b.footer(), width))
+	return lines[:min(len(lines), height)]
+}
+
+func (b *browser) header() string {
+	header := fmt.Sprintf(" %s  %d of %d events", b.opts.Title, len(b.visible), len(b.items))
+	if b.opts.Fetch != nil {
+		header += fmt.Sprintf(", page %d", b.page)
+		if b.done {
+			header += " (last)"
+		}
+	}
+	if b.query != "" && !b.typing {
+		header += fmt.Sprintf("  filter: %s", b.query)
+	}
+	return header
+}
+
+func (b *browser) footer() string {
+	switch {
+	case b.typing:
+		return "/" + b.query + "█"
+	case b.status != "":
+		return b.status
+	case len(b.visible) == 0 && b.query != "":
+		return fmt.Sprintf("No events match '%s', press esc to clear", b.query)
+	case b.opts.Fetch != nil:
+		return PAGING_HELP_LINE
+	default:
+		return HELP_LINE
+	}
+}
+
+// details describes the selected event: where and when it happened, its
+// URL, the commits, titles or comments it carries and its raw payload.
+func (b *browser) details() []string {
+	selected, ok := b.selected()
+	if !ok {
+		return nil
+	}
+	event := selected.event
+
+	lines := []string{
+		selected.summary,
+		fmt.Sprintf("Type:    %s", event.Type),
+		fmt.Sprintf("Repo:    %s", event.Repo.Name),
+		fmt.Sprintf("Actor:   %s", event.Actor.Login),
+		fmt.Sprintf("Created: %s", event.CreatedAt.In(b.opts.Location).Format(time.RFC1123)),
+		fmt.Sprintf("URL:     %s", events.HTMLURL(event, b.opts.Host)),
+	}
+
+	if details := render.Details(event); len(details) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, details...)
+	}
+
+	if payload := payloadLines(event); len(payload) > 0 {
+		lines = append(lines, "", "Payload:")
+		lines = append(lines, payload...)
+	}
+
+	return lines
+}
+
+// payloadLines formats the payload of event as indented JSON, leaving out the
+// fields its type doesn't use.
+func payloadLines(event events.Event) []string {
+	data, err := json.Marshal(event.Payload)
+	if err != nil {
+		return nil
+	}
+
+	var payload any
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil
+	}
+	payload = prune(payload)
+	if payload == nil {
+		return nil
+	}
+
+	data, err = json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return nil
+	}
+	return strings.Split(string(data), "\n")
+}
+
+// prune drops the empty strings, zeros, false values and empty collections
+// from a decoded JSON value, returning nil when nothing is left.
+func prune(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, field := range value {
+			if field = prune(field); field == nil {
+				delete(value, key)
+			} else {
+				value[key] = field
+			}
+		}
+		if len(value) == 0 {
+			return nil
+		}
+		return value
+	case []any:
+		var elements []any
+		for _, element := range value {
+			if element = prune(element); element != nil {
+				elements = append(elements, element)
+			}
+		}
+		if len(elements) == 0 {
+			return nil
+		}
+		return elements
+	case string:
+		if value == "" {
+			return nil
+		}
+	case float64:
+		if value == 0 {
+			return nil
+		}
+	case bool:
+		if !value {
+			return nil
+		}
+	}
+	return value
+}
+
+// truncate cuts line to width characters, marking the cut with an ellipsis.
+func truncate(line string, width int) string {
+	line = sanitize(line)
+	if width <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(line) <= width {
+		return line
+	}
+
+	runes := []rune(line)
+	return string(runes[:width-1]) + ELLIPSIS
+}
+
+// pad truncates line to width and fills the rest with spaces, so reverse
+// video covers the whole row.
+func pad(line string, width int) string {
+	line = truncate(line, width)
+	return line + strings.Repeat(" ", max(width-utf8.RuneCountInString(line), 0))
+}
+
+// sanitize replaces control characters so text from events can't move the
+// cursor or restyle the screen.
+func sanitize(line string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, line)
+}
@@ -0,0 +1,350 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+	"github.com/dmitriy-zverev/github-activity/filter"
+)
+
+func eventFromJSON(t *testing.T, data string) events.Event {
+	t.Helper()
+
+	var event events.Event
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		t.Fatalf("Couldn't decode test event: %v", err)
+	}
+
+	return event
+}
+
+// testPage returns count push events to distinct repos, newest first, with
+// IDs and times that continue across pages.
+func testPage(t *testing.T, page, count int) []events.Event {
+	t.Helper()
+
+	var activities []events.Event
+	for i := range count {
+		n := (page-1)*count + i
+		createdAt := time.Date(2025, 1, 28, 10, 0, 0, 0, time.UTC).Add(-time.Duration(n) * time.Hour)
+		activities = append(activities, eventFromJSON(t, fmt.Sprintf(
+			`{"id":"%d","type":"PushEvent","created_at":"%s","repo":{"name":"octocat/repo-%d"},"payload":{"size":1,"commits":[{"sha":"abc%d","message":"Commit %d"}]}}`,
+			n, createdAt.Format(time.RFC3339), n, n, n,
+		)))
+	}
+	return activities
+}
+
+func pressKeys(b *browser, keys ...string) {
+	for _, key := range keys {
+		b.handleKey(key)
+	}
+}
+
+func TestBrowserView(t *testing.T) {
+	pr := eventFromJSON(t, `{
+		"id": "1",
+		"type": "PullRequestEvent",
+		"created_at": "2025-01-02T15:04:00Z",
+		"actor": {"login": "octocat"},
+		"repo": {"name": "octocat/hello"},
+		"payload": {"action": "opened", "number": 7, "pull_request": {"title": "Add parser"}}
+	}`)
+	b := newBrowser(append([]events.Event{pr}, testPage(t, 1, 3)...), Options{Title: "octocat"})
+
+	lines := b.view(60, 40)
+	if len(lines) != 40 {
+		t.Fatalf("Expected 40 lines, got %d", len(lines))
+	}
+	screen := strings.Join(lines, "\n")
+
+	for _, expected := range []string{
+		"octocat  4 of 4 events",
+		"2025-01-02 15:04  Pull request 'Add parser' opened at octo…",
+		"URL:     https://github.com/octocat/hello/pull/7",
+		"Pull request #7: Add parser (opened)",
+		`"number": 7`,
+		HELP_LINE,
+	} {
+		if !strings.Contains(screen, expected) {
+			t.Errorf("Expected screen to contain %q, got:\n%s", expected, screen)
+		}
+	}
+
+	if !strings.HasPrefix(lines[1], REVERSE) {
+		t.Errorf("Expected the first event to be selected, got %q", lines[1])
+	}
+	for _, line := range lines {
+		plain := strings.NewReplacer(REVERSE, "", BOLD, "", DIM, "", RESET, "").Replace(line)
+		if utf8.RuneCountInString(plain) > 60 {
+			t.Errorf("Expected lines of at most 60 characters, got %q", plain)
+		}
+	}
+}
+
+func TestBrowserScrolling(t *testing.T) {
+	b := newBrowser(testPage(t, 1, 30), Options{Title: "octocat"})
+	b.view(80, 13)
+
+	pressKeys(b, KEY_PAGE_DOWN, KEY_DOWN)
+	lines := b.view(80, 13)
+	if b.cursor != b.listHeight()+1 {
+		t.Errorf("Expected cursor at %d, got %d", b.listHeight()+1, b.cursor)
+	}
+	if !strings.Contains(lines[b.cursor-b.offset+1], "octocat/repo-6") || !strings.HasPrefix(lines[b.cursor-b.offset+1], REVERSE) {
+		t.Errorf("Expected the selected row on screen, got %q", lines)
+	}
+
+	pressKeys(b, "G")
+	b.view(80, 13)
+	if b.cursor != 29 || b.offset != 30-b.listHeight() {
+		t.Errorf("Expected the list scrolled to its end, got cursor %d offset %d", b.cursor, b.offset)
+	}
+
+	pressKeys(b, "g", KEY_UP)
+	if b.cursor != 0 {
+		t.Errorf("Expected cursor at the top, got %d", b.cursor)
+	}
+}
+
+func TestBrowserFilter(t *testing.T) {
+	b := newBrowser(testPage(t, 1, 12), Options{Title: "octocat"})
+	pressKeys(b, KEY_DOWN, KEY_DOWN)
+
+	pressKeys(b, "/", "r", "e", "p", "o", "-", "1")
+	if !b.typing || len(b.visible) != 3 {
+		t.Fatalf("Expected repo-1, repo-10 and repo-11 while typing, got %d", len(b.visible))
+	}
+	if footer := b.footer(); footer != "/repo-1█" {
+		t.Errorf("Expected the query in the footer, got %q", footer)
+	}
+
+	pressKeys(b, "q")
+	if len(b.visible) != 0 || b.query != "repo-1q" {
+		t.Errorf("Expected q to be typed rather than quit, got query %q", b.query)
+	}
+
+	pressKeys(b, KEY_BACKSPACE, "0", KEY_ENTER)
+	if b.typing || len(b.visible) != 1 {
+		t.Errorf("Expected repo-10 only after enter, got %d", len(b.visible))
+	}
+	if !strings.Contains(b.header(), "filter: repo-10") {
+		t.Errorf("Expected the filter in the header, got %q", b.header())
+	}
+
+	pressKeys(b, KEY_ESCAPE)
+	if len(b.visible) != 12 || b.query != "" {
+		t.Errorf("Expected escape to clear the filter, got %d events", len(b.visible))
+	}
+
+	pressKeys(b, "/", "c", "o", "m", "m", "i", "t", " ", "5", KEY_ENTER)
+	if selected, ok := b.selected(); !ok || selected.event.Repo.Name != "octocat/repo-5" {
+		t.Errorf("Expected commit messages to be searched, got %+v", selected.event.Repo)
+	}
+}
+
+func TestBrowserKeepsSelection(t *testing.T) {
+	b := newBrowser(testPage(t, 1, 5), Options{Title: "octocat"})
+	pressKeys(b, KEY_DOWN, KEY_DOWN, KEY_DOWN)
+
+	pressKeys(b, "/", "r", "e", "p", "o")
+	if selected, _ := b.selected(); selected.event.Repo.Name != "octocat/repo-3" {
+		t.Errorf("Expected repo-3 to stay selected, got %s", selected.event.Repo.Name)
+	}
+}
+
+func TestBrowserPaging(t *testing.T) {
+	var requested []int
+	fetch := func(ctx context.Context, page int) ([]events.Event, error) {
+		requested = append(requested, page)
+		switch {
+		case page == 2:
+			return testPage(t, 2, 5), nil
+		case page == 3 && len(requested) == 2:
+			return nil, errors.New("couldn't get response from github: 502 Bad Gateway")
+		default:
+			return nil, nil
+		}
+	}
+	b := newBrowser(testPage(t, 1, 5), Options{Title: "octocat", Page: 1, Fetch: fetch})
+
+	if b.wantsMore() {
+		t.Fatal("Expected no fetch before reaching the end of the list")
+	}
+	pressKeys(b, "G")
+	if !b.wantsMore() {
+		t.Fatal("Expected a fetch at the end of the list")
+	}
+
+	b.loadMore(context.Background())
+	if len(b.visible) != 10 || b.page != 2 || b.cursor != 4 {
+		t.Errorf("Expected page 2 appended with the selection kept, got %d events, page %d, cursor %d", len(b.visible), b.page, b.cursor)
+	}
+
+	pressKeys(b, "G")
+	b.loadMore(context.Background())
+	if !strings.Contains(b.status, "Couldn't load page 3") || b.page != 2 || b.done {
+		t.Errorf("Expected a failed page to be reported and retried later, got %q", b.status)
+	}
+
+	pressKeys(b, "n")
+	b.loadMore(context.Background())
+	if !b.done || b.status != "No more events" {
+		t.Errorf("Expected an empty page to end paging, got %q", b.status)
+	}
+	if !strings.Contains(b.header(), "page 2 (last)") {
+		t.Errorf("Expected the last page in the header, got %q", b.header())
+	}
+
+	pressKeys(b, "G", "n")
+	if b.wantsMore() || b.status != "No more events" {
+		t.Errorf("Expected no fetch after the last page, got %q", b.status)
+	}
+	if len(requested) != 3 {
+		t.Errorf("Expected pages 2, 3 and 3 to be requested, got %v", requested)
+	}
+}
+
+func TestBrowserFetchesUntilFiltersMatch(t *testing.T) {
+	release := eventFromJSON(t, `{"id":"r","type":"ReleaseEvent","created_at":"2024-12-01T00:00:00Z","repo":{"name":"octocat/hello"},"payload":{"release":{"name":"v1"}}}`)
+	fetch := func(ctx context.Context, page int) ([]events.Event, error) {
+		if page == 3 {
+			return []events.Event{release}, nil
+		}
+		return testPage(t, page, 5), nil
+	}
+	b := newBrowser(testPage(t, 1, 5), Options{
+		Title:   "octocat",
+		Page:    1,
+		Fetch:   fetch,
+		Filters: filter.Options{Type: events.RELEASE_EVENT},
+	})
+
+	for b.wantsMore() {
+		b.loadMore(context.Background())
+	}
+	if b.page != 3 || len(b.visible) != 1 {
+		t.Errorf("Expected loading to stop at page 3 with the release, got page %d with %d events", b.page, len(b.visible))
+	}
+}
+
+func TestBrowserCollapse(t *testing.T) {
+	first := eventFromJSON(t, `{"id":"1","type":"PushEvent","repo":{"name":"octocat/hello"},"payload":{"commits":[{"sha":"aaa","message":"First"}]}}`)
+	second := eventFromJSON(t, `{"id":"2","type":"PushEvent","repo":{"name":"octocat/hello"},"payload":{"commits":[{"sha":"bbb","message":"Second"}]}}`)
+
+	b := newBrowser([]events.Event{first, second}, Options{Collapse: true})
+	if len(b.visible) != 1 {
+		t.Fatalf("Expected the pushes collapsed, got %d events", len(b.visible))
+	}
+	details := strings.Join(b.details(), "\n")
+	if !strings.Contains(details, "aaa First") || !strings.Contains(details, "bbb Second") {
+		t.Errorf("Expected the commits of both pushes, got:\n%s", details)
+	}
+}
+
+func TestBrowserOpen(t *testing.T) {
+	b := newBrowser([]events.Event{
+		eventFromJSON(t, `{"type":"IssuesEvent","repo":{"name":"o/r"},"payload":{"issue":{"number":3}}}`),
+	}, Options{Host: "ghe.example.com"})
+
+	var opened string
+	b.open = func(url string) error {
+		opened = url
+		return nil
+	}
+	pressKeys(b, "o")
+	if opened != "https://ghe.example.com/o/r/issues/3" || b.status != "Opened "+opened {
+		t.Errorf("Expected the issue to be opened, got %q with status %q", opened, b.status)
+	}
+
+	b.open = func(url string) error { return errors.New("no browser") }
+	pressKeys(b, KEY_ENTER)
+	if !strings.Contains(b.status, "no browser") {
+		t.Errorf("Expected the failure in the status line, got %q", b.status)
+	}
+
+	for _, issueURL := range []string{"http://ghe.example.com/o/r/issues/3", "https://evil.example.com/o/r/issues/3", "file:///etc/passwd", "--help"} {
+		b := newBrowser([]events.Event{
+			eventFromJSON(t, `{"type":"IssuesEvent","repo":{"name":"o/r"},"payload":{"issue":{"number":3,"html_url":"`+issueURL+`"}}}`),
+		}, Options{Host: "ghe.example.com"})
+		b.open = func(url string) error {
+			t.Errorf("Expected %s not to be opened", url)
+			return nil
+		}
+		pressKeys(b, "o")
+		if !strings.HasPrefix(b.status, "Won't open") {
+			t.Errorf("Expected %s to be refused, got status %q", issueURL, b.status)
+		}
+	}
+}
+
+func TestSafeURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		host     string
+		expected bool
+	}{
+		{url: "https://github.com/o/r/pull/1", expected: true},
+		{url: "https://GitHub.com/o/r", host: "github.com", expected: true},
+		{url: "https://ghe.example.com/o/r", host: "ghe.example.com", expected: true},
+		{url: "https://github.com/o/r", host: "ghe.example.com", expected: false},
+		{url: "http://github.com/o/r", expected: false},
+		{url: "javascript:alert(1)", expected: false},
+		{url: "-new-window", expected: false},
+	}
+
+	for _, tt := range tests {
+		if got := safeURL(tt.url, tt.host); got != tt.expected {
+			t.Errorf("safeURL(%q, %q) = %v, want %v", tt.url, tt.host, got, tt.expected)
+		}
+	}
+}
+
+func TestBrowserQuit(t *testing.T) {
+	b := newBrowser(nil, Options{})
+	if !b.handleKey("q") || !b.handleKey(KEY_CTRL_C) {
+		t.Error("Expected q and ctrl-c to quit")
+	}
+	if b.handleKey("j") {
+		t.Error("Expected j not to quit")
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		line     string
+		width    int
+		expected string
+	}{
+		{line: "short", width: 10, expected: "short"},
+		{line: "exactly10!", width: 10, expected: "exactly10!"},
+		{line: "much longer line", width: 8, expected: "much lo…"},
+		{line: "naïve café", width: 6, expected: "naïve…"},
+		{line: "evil\x1b[2Jtitle\r", width: 20, expected: "evil [2Jtitle "},
+		{line: "anything", width: 0, expected: ""},
+	}
+
+	for _, tt := range tests {
+		if result := truncate(tt.line, tt.width); result != tt.expected {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.line, tt.width, result, tt.expected)
+		}
+	}
+}
+
+func TestPayloadLines(t *testing.T) {
+	event := eventFromJSON(t, `{"type":"WatchEvent","payload":{"action":"started"}}`)
+	if lines := payloadLines(event); strings.Join(lines, "\n") != "{\n  \"action\": \"started\"\n}" {
+		t.Errorf("Expected only the action, got %q", lines)
+	}
+
+	if lines := payloadLines(eventFromJSON(t, `{"type":"PublicEvent"}`)); lines != nil {
+		t.Errorf("Expected no payload, got %q", lines)
+	}
+}
@@ -0,0 +1,46 @@
+package tui
+
+const (
+	TTY_PATH     = "/dev/tty"
+	STTY_COMMAND = "stty"
+)
+
+const (
+	ENTER_ALT_SCREEN = "\033[?1049h"
+	LEAVE_ALT_SCREEN = "\033[?1049l"
+	HIDE_CURSOR      = "\033[?25l"
+	SHOW_CURSOR      = "\033[?25h"
+	MOVE_CURSOR      = "\033[%d;1H"
+	CLEAR_LINE       = "\033[K"
+	REVERSE          = "\033[7m"
+	BOLD             = "\033[1m"
+	DIM              = "\033[2m"
+	RESET            = "\033[0m"
+)
+
+const (
+	KEY_UP        = "up"
+	KEY_DOWN      = "down"
+	KEY_PAGE_UP   = "pgup"
+	KEY_PAGE_DOWN = "pgdn"
+	KEY_HOME      = "home"
+	KEY_END       = "end"
+	KEY_ENTER     = "enter"
+	KEY_ESCAPE    = "esc"
+	KEY_BACKSPACE = "backspace"
+	KEY_CTRL_C    = "ctrl-c"
+	KEY_UNKNOWN   = ""
+)
+
+const (
+	DEFAULT_WIDTH   = 80
+	DEFAULT_HEIGHT  = 24
+	MIN_LIST_HEIGHT = 3
+	KEY_BUFFER_SIZE = 64
+
+	DATE_TIME_FORMAT = "2006-01-02 15:04"
+	ELLIPSIS         = "…"
+	SEPARATOR        = "─"
+	HELP_LINE        = "↑↓ move  / filter  o open  q quit"
+	PAGING_HELP_LINE = "↑↓ move  / filter  o open  n more  q quit"
+)
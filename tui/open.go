@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/dmitriy-zverev/github-activity/events"
+)
+
+// safeURL reports whether rawURL is an https page on host, github.com when
+// empty. Event URLs come from the API payload, so anything else, such as a
+// file: URL or a string a browser would take for an option, is not opened.
+func safeURL(rawURL, host string) bool {
+	if host == "" {
+		host = events.DEFAULT_WEB_HOST
+	}
+
+	parsed, err := url.Parse(rawURL)
+	return err == nil && parsed.Scheme == "https" && strings.EqualFold(parsed.Host, host)
+}
+
+// openURL shows url in the user's browser: $BROWSER when set, otherwise the
+// platform's opener. Callers check url with safeURL first.
+func openURL(url string) error {
+	var cmd *exec.Cmd
+	switch {
+	case os.Getenv("BROWSER") != "":
+		// #nosec G204 -- $BROWSER is the user's own choice and url passed safeURL
+		cmd = exec.Command(os.Getenv("BROWSER"), url)
+	case runtime.GOOS == "darwin":
+		cmd = exec.Command("open", url) // #nosec G204 -- url passed safeURL
+	case runtime.GOOS == "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url) // #nosec G204 -- url passed safeURL
+	default:
+		cmd = exec.Command("xdg-open", url) // #nosec G204 -- url passed safeURL
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
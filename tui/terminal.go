@@ -0,0 +1,172 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// terminal is the controlling terminal in raw mode. It is opened directly
+// rather than through stdin and stdout, so events can still be piped in with
+// --input -.
+type terminal struct {
+	tty   *os.File
+	state string
+}
+
+// openTerminal switches the controlling terminal to raw mode and the
+// alternate screen. stty does the switch so no platform specific ioctls are
+// needed.
+func openTerminal() (*terminal, error) {
+	tty, err := os.OpenFile(TTY_PATH, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := stty(tty, "-g")
+	if err != nil {
+		tty.Close()
+		return nil, err
+	}
+	if _, err := stty(tty, "raw", "-echo"); err != nil {
+		tty.Close()
+		return nil, err
+	}
+
+	fmt.Fprint(tty, ENTER_ALT_SCREEN+HIDE_CURSOR)
+	return &terminal{tty: tty, state: state}, nil
+}
+
+// restore puts the terminal back the way openTerminal found it.
+func (term *terminal) restore() {
+	fmt.Fprint(term.tty, SHOW_CURSOR+LEAVE_ALT_SCREEN)
+	stty(term.tty, term.state)
+	term.tty.Close()
+}
+
+// size returns the width and height of the terminal, or a default when stty
+// can't tell.
+func (term *terminal) size() (int, int) {
+	output, err := stty(term.tty, "size")
+	if err != nil {
+		return DEFAULT_WIDTH, DEFAULT_HEIGHT
+	}
+
+	var height, width int
+	if _, err := fmt.Sscan(output, &height, &width); err != nil || width < 1 || height < 1 {
+		return DEFAULT_WIDTH, DEFAULT_HEIGHT
+	}
+	return width, height
+}
+
+// draw replaces the screen with lines, one per row.
+func (term *terminal) draw(lines []string) error {
+	var frame strings.Builder
+	for i, line := range lines {
+		fmt.Fprintf(&frame, MOVE_CURSOR, i+1)
+		frame.WriteString(line)
+		frame.WriteString(CLEAR_LINE)
+	}
+
+	_, err := term.tty.WriteString(frame.String())
+	return err
+}
+
+// readKeys blocks until at least one key was pressed.
+func (term *terminal) readKeys() ([]string, error) {
+	buf := make([]byte, KEY_BUFFER_SIZE)
+	n, err := term.tty.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	return parseKeys(buf[:n]), nil
+}
+
+func stty(tty *os.File, args ...string) (string, error) {
+	cmd := exec.Command(STTY_COMMAND, args...)
+	cmd.Stdin = tty
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s %s: %w", STTY_COMMAND, strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// parseKeys splits what a read from the terminal returned into keys. Printable
+// characters are returned as themselves, everything else as one of the KEY_*
+// constants.
+func parseKeys(input []byte) []string {
+	var keys []string
+
+	for len(input) > 0 {
+		key, size := parseKey(input)
+		if key != KEY_UNKNOWN {
+			keys = append(keys, key)
+		}
+		input = input[size:]
+	}
+
+	return keys
+}
+
+func parseKey(input []byte) (string, int) {
+	switch input[0] {
+	case 3:
+		return KEY_CTRL_C, 1
+	case '\r', '\n':
+		return KEY_ENTER, 1
+	case 8, 127:
+		return KEY_BACKSPACE, 1
+	case 27:
+		return parseEscape(input)
+	}
+
+	r, size := utf8.DecodeRune(input)
+	if r == utf8.RuneError || r < ' ' {
+		return KEY_UNKNOWN, size
+	}
+	return string(r), size
+}
+
+// parseEscape reads an escape sequence such as ESC [ A. A lone ESC is the
+// escape key.
+func parseEscape(input []byte) (string, int) {
+	if len(input) < 2 || (input[1] != '[' && input[1] != 'O') {
+		return KEY_ESCAPE, 1
+	}
+
+	end := 2
+	for end < len(input) && (input[end] < 0x40 || input[end] > 0x7e) {
+		end++
+	}
+	if end == len(input) {
+		return KEY_UNKNOWN, len(input)
+	}
+
+	switch params, final := string(input[2:end]), input[end]; final {
+	case 'A':
+		return KEY_UP, end + 1
+	case 'B':
+		return KEY_DOWN, end + 1
+	case 'H':
+		return KEY_HOME, end + 1
+	case 'F':
+		return KEY_END, end + 1
+	case '~':
+		number, _ := strconv.Atoi(params)
+		switch number {
+		case 1, 7:
+			return KEY_HOME, end + 1
+		case 4, 8:
+			return KEY_END, end + 1
+		case 5:
+			return KEY_PAGE_UP, end + 1
+		case 6:
+			return KEY_PAGE_DOWN, end + 1
+		}
+	}
+	return KEY_UNKNOWN, end + 1
+}
@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{name: "Letters", input: "jk/", expected: []string{"j", "k", "/"}},
+		{name: "Unicode", input: "é", expected: []string{"é"}},
+		{name: "Arrows", input: "\x1b[A\x1b[B\x1bOA", expected: []string{KEY_UP, KEY_DOWN, KEY_UP}},
+		{name: "Paging", input: "\x1b[5~\x1b[6~", expected: []string{KEY_PAGE_UP, KEY_PAGE_DOWN}},
+		{name: "Home and end", input: "\x1b[H\x1b[F\x1b[1~\x1b[4~", expected: []string{KEY_HOME, KEY_END, KEY_HOME, KEY_END}},
+		{name: "Lone escape", input: "\x1b", expected: []string{KEY_ESCAPE}},
+		{name: "Control keys", input: "\r\x7f\x03", expected: []string{KEY_ENTER, KEY_BACKSPACE, KEY_CTRL_C}},
+		{name: "Unknown sequence", input: "\x1b[2~x", expected: []string{"x"}},
+		{name: "Other control character", input: "\x01a", expected: []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if keys := parseKeys([]byte(tt.input)); !reflect.DeepEqual(keys, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, keys)
+			}
+		})
+	}
+}